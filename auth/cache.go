@@ -0,0 +1,25 @@
+package auth
+
+import "sync"
+
+// ClientCache holds the client built for an AuthenticatedContext by package
+// client, so that every request made with the context shares its
+// connections, and the client is freed along with the context.
+type ClientCache struct {
+	mu     sync.Mutex
+	client any
+}
+
+// Load returns the cached client, creating it with newClient on first use.
+func (cc *ClientCache) Load(newClient func() (any, error)) (any, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.client == nil {
+		client, err := newClient()
+		if err != nil {
+			return nil, err
+		}
+		cc.client = client
+	}
+	return cc.client, nil
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The environment variables read by ParamsFromEnv.
+const (
+	EnvClientId     = "FRAGMENT_CLIENT_ID"
+	EnvClientSecret = "FRAGMENT_CLIENT_SECRET"
+	EnvScope        = "FRAGMENT_SCOPE"
+	EnvAuthUrl      = "FRAGMENT_AUTH_URL"
+	EnvApiUrl       = "FRAGMENT_API_URL"
+	// EnvClientSecretFile names a file holding the client secret. It is read
+	// on every token request, and used when FRAGMENT_CLIENT_SECRET is unset.
+	EnvClientSecretFile = "FRAGMENT_CLIENT_SECRET_FILE"
+	// EnvProfile selects the profile read by ParamsFromFile when none is provided.
+	EnvProfile = "FRAGMENT_PROFILE"
+)
+
+// DefaultProfile is the profile read by ParamsFromFile when none is provided
+// and FRAGMENT_PROFILE is unset.
+const DefaultProfile = "default"
+
+// ParamsFromEnv returns token parameters read from the FRAGMENT_CLIENT_ID,
+// FRAGMENT_CLIENT_SECRET (or FRAGMENT_CLIENT_SECRET_FILE), FRAGMENT_SCOPE,
+// FRAGMENT_AUTH_URL and FRAGMENT_API_URL environment variables.
+func ParamsFromEnv() (*GetTokenParams, error) {
+	params := &GetTokenParams{
+		ClientId:     os.Getenv(EnvClientId),
+		ClientSecret: os.Getenv(EnvClientSecret),
+		Scope:        os.Getenv(EnvScope),
+		AuthUrl:      os.Getenv(EnvAuthUrl),
+		ApiUrl:       os.Getenv(EnvApiUrl),
+	}
+	if secretFile := os.Getenv(EnvClientSecretFile); params.ClientSecret == "" && secretFile != "" {
+		params.SecretResolver = FileSecretResolver(secretFile)
+	}
+	if err := validateParams(params); err != nil {
+		return nil, fmt.Errorf("Invalid credentials in environment: %w", err)
+	}
+	return params, nil
+}
+
+// DefaultCredentialsPath returns the path of the credentials file read by
+// ParamsFromFile when no path is provided, ~/.fragment/credentials.
+func DefaultCredentialsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".fragment", "credentials"), nil
+}
+
+// ParamsFromFile returns the token parameters for a named profile in a
+// credentials file. The file is either JSON, mapping profile names to objects
+// with the same fields as GetTokenParams, or INI:
+//
+//	[sandbox]
+//	client_id = ...
+//	client_secret = ...
+//	scope = ...
+//	auth_url = ...
+//	api_url = ...
+//
+// If path is empty, DefaultCredentialsPath is used. If profile is empty, the
+// FRAGMENT_PROFILE environment variable is used, falling back to "default".
+func ParamsFromFile(path string, profile string) (*GetTokenParams, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultCredentialsPath(); err != nil {
+			return nil, err
+		}
+	}
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles map[string]*GetTokenParams
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		profiles, err = parseJsonProfiles(data)
+	} else {
+		profiles, err = parseIniProfiles(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse credentials file %s: %w", path, err)
+	}
+
+	params, ok := profiles[profile]
+	if !ok || params == nil {
+		return nil, fmt.Errorf("Profile %s not found in credentials file %s", profile, path)
+	}
+	if err := validateParams(params); err != nil {
+		return nil, fmt.Errorf("Invalid credentials for profile %s in %s: %w", profile, path, err)
+	}
+	return params, nil
+}
+
+// parseJsonProfiles rejects unknown keys, like parseIniProfiles, so that a
+// misspelled key isn't silently ignored.
+func parseJsonProfiles(data []byte) (map[string]*GetTokenParams, error) {
+	var profiles map[string]*GetTokenParams
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profiles); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the profiles")
+	}
+	return profiles, nil
+}
+
+func parseIniProfiles(data []byte) (map[string]*GetTokenParams, error) {
+	profiles := map[string]*GetTokenParams{}
+	var current *GetTokenParams
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			current = &GetTokenParams{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || current == nil {
+			return nil, fmt.Errorf("line %d: expected a [profile] header or key = value", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "client_id":
+			current.ClientId = value
+		case "client_secret":
+			current.ClientSecret = value
+		case "scope":
+			current.Scope = value
+		case "auth_url":
+			current.AuthUrl = value
+		case "api_url":
+			current.ApiUrl = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %s", lineNumber, strings.TrimSpace(key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// validateParams checks that every required field is set before
+// validating the parameters themselves.
+func validateParams(params *GetTokenParams) error {
+	var missing []string
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"client_id", params.ClientId},
+		{"scope", params.Scope},
+		{"auth_url", params.AuthUrl},
+		{"api_url", params.ApiUrl},
+	} {
+		if field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if params.ClientSecret == "" && params.SecretResolver == nil {
+		missing = append(missing, "client_secret")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	return params.IsValid()
+}
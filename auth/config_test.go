@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setCredentialsEnv(t *testing.T) {
+	t.Setenv(EnvClientId, "env_client_id")
+	t.Setenv(EnvClientSecret, "env_client_secret")
+	t.Setenv(EnvScope, "*")
+	t.Setenv(EnvAuthUrl, "https://auth.example.com/oauth2/token")
+	t.Setenv(EnvApiUrl, "https://api.example.com/graphql")
+}
+
+func TestParamsFromEnv(t *testing.T) {
+	setCredentialsEnv(t)
+
+	params, err := ParamsFromEnv()
+	if err != nil {
+		t.Errorf("Got error from ParamsFromEnv: %s", err)
+	}
+	if params.ClientId != "env_client_id" || params.ClientSecret != "env_client_secret" {
+		t.Errorf("Unexpected params %+v", params)
+	}
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file_client_secret\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret file: %s", err)
+	}
+	t.Setenv(EnvClientSecret, "")
+	t.Setenv(EnvClientSecretFile, secretFile)
+	params, err = ParamsFromEnv()
+	if err != nil {
+		t.Errorf("Got error from ParamsFromEnv: %s", err)
+	}
+	if secret, _ := params.SecretResolver.ResolveSecret(context.TODO()); secret != "file_client_secret" {
+		t.Errorf("Expected secret file_client_secret, got %s", secret)
+	}
+
+	t.Setenv(EnvApiUrl, "")
+	if _, err := ParamsFromEnv(); err == nil || !strings.Contains(err.Error(), "api_url") {
+		t.Errorf("Expected an error naming api_url, got %v", err)
+	}
+}
+
+func TestParamsFromFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"credentials": `
+# Fragment credentials
+[sandbox]
+client_id = sandbox_client_id
+client_secret = sandbox_client_secret
+scope = *
+auth_url = https://auth.example.com/oauth2/token
+api_url = https://api.example.com/graphql
+
+[production]
+client_id = production_client_id
+`,
+		"credentials.json": `{
+  "sandbox": {
+    "client_id": "sandbox_client_id",
+    "client_secret": "sandbox_client_secret",
+    "scope": "*",
+    "auth_url": "https://auth.example.com/oauth2/token",
+    "api_url": "https://api.example.com/graphql"
+  }
+}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %s", name, err)
+		}
+
+		params, err := ParamsFromFile(path, "sandbox")
+		if err != nil {
+			t.Errorf("Got error from ParamsFromFile for %s: %s", name, err)
+			continue
+		}
+		if params.ClientId != "sandbox_client_id" || params.ApiUrl != "https://api.example.com/graphql" {
+			t.Errorf("Unexpected params from %s: %+v", name, params)
+		}
+
+		if _, err := ParamsFromFile(path, "staging"); err == nil {
+			t.Errorf("Expected an error for a missing profile in %s", name)
+		}
+	}
+
+	if _, err := ParamsFromFile(filepath.Join(dir, "credentials"), "production"); err == nil {
+		t.Errorf("Expected an error for an incomplete profile")
+	}
+
+	misspelled := filepath.Join(dir, "misspelled.json")
+	content := `{"sandbox": {"client_id": "id", "client_secert": "secret", "scope": "*", "auth_url": "https://auth.example.com", "api_url": "https://api.example.com"}}`
+	if err := os.WriteFile(misspelled, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write misspelled.json: %s", err)
+	}
+	if _, err := ParamsFromFile(misspelled, "sandbox"); err == nil || !strings.Contains(err.Error(), "client_secert") {
+		t.Errorf("Expected an error for an unknown key, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrInvalidClient is matched by a TokenError when the token endpoint
+	// rejects the client ID or client secret.
+	ErrInvalidClient = errors.New("invalid client credentials")
+	// ErrInvalidScope is matched by a TokenError when the token endpoint
+	// rejects the requested scope.
+	ErrInvalidScope = errors.New("invalid scope")
+	// ErrAuthServerUnavailable is matched by a TokenError when the token
+	// endpoint fails with a server error.
+	ErrAuthServerUnavailable = errors.New("auth server unavailable")
+)
+
+// TokenError is returned by GetToken when the token endpoint responds
+// with a non-OK status.
+type TokenError struct {
+	// The HTTP status code of the response.
+	StatusCode int
+	// The OAuth error code, such as "invalid_client", if one was returned.
+	Code string
+	// The OAuth error description, if one was returned.
+	Description string
+	// The raw response body.
+	Body []byte
+}
+
+func (e *TokenError) Error() string {
+	msg := fmt.Sprintf("Received non-OK status %d from the token endpoint", e.StatusCode)
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += " (" + e.Description + ")"
+	}
+	return msg
+}
+
+// Is reports whether the error matches ErrInvalidClient, ErrInvalidScope
+// or ErrAuthServerUnavailable.
+func (e *TokenError) Is(target error) bool {
+	switch target {
+	case ErrInvalidClient:
+		return e.Code == "invalid_client"
+	case ErrInvalidScope:
+		return e.Code == "invalid_scope"
+	case ErrAuthServerUnavailable:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
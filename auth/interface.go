@@ -1,6 +1,9 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"net/http"
+)
 
 type TokenParams interface {
 	GetClientId() string
@@ -27,3 +30,83 @@ type AuthenticatedContext interface {
 	// GetToken returns the access token from the context.
 	GetToken() (*Token, bool)
 }
+
+// TokenStoreProvider is implemented by an AuthenticatedContext that holds its
+// access token in a TokenStore shared by every goroutine using the context,
+// like those returned by GetAuthenticatedContext and NewAuthenticatedContext.
+// The client type-asserts for it, and otherwise uses GetToken and SetToken.
+type TokenStoreProvider interface {
+	// GetTokenStore returns the store holding the access token.
+	GetTokenStore() TokenStore
+}
+
+// TokenSourceProvider is implemented by an AuthenticatedContext that fetches
+// new access tokens from a TokenSource. The client type-asserts for it, and
+// otherwise fetches them with the client_credentials grant, using the
+// context's token parameters.
+type TokenSourceProvider interface {
+	// GetTokenSource returns the source used to fetch a new access token
+	// once the stored one expires.
+	GetTokenSource() TokenSource
+}
+
+// HTTPClientProvider is implemented by an AuthenticatedContext that carries
+// the http.Client to use for its requests, like those created with
+// WithHTTPClient or WithTransport. The client type-asserts for it, and
+// otherwise uses a default client.
+type HTTPClientProvider interface {
+	// GetHTTPClient returns the http.Client to use for requests made with
+	// the context, or nil to use a default client.
+	GetHTTPClient() *http.Client
+}
+
+// ClientCacheProvider is implemented by an AuthenticatedContext that caches
+// its client, like those returned by GetAuthenticatedContext and
+// NewAuthenticatedContext. The client type-asserts for it, and otherwise
+// creates a new client for every request.
+type ClientCacheProvider interface {
+	// GetClientCache returns the cache holding the context's client.
+	GetClientCache() *ClientCache
+}
+
+// TokenSource defines the interface for anything that can supply a
+// Fragment API access token. It has the same shape as
+// golang.org/x/oauth2.TokenSource; see FromOAuth2 and ToOAuth2.
+type TokenSource interface {
+	// Token returns an access token.
+	Token() (*Token, error)
+}
+
+// TokenStore defines the interface for a concurrency-safe holder of
+// the Fragment API access token.
+type TokenStore interface {
+	// Get returns the current access token, if one has been stored.
+	Get() (*Token, bool)
+
+	// Set replaces the current access token.
+	Set(*Token)
+
+	// Refresh replaces stale with a token returned by fetch. Concurrent
+	// callers share a single in-flight fetch, and if the store already
+	// holds a token other than stale it is returned without fetching.
+	Refresh(stale *Token, fetch func() (*Token, error)) (*Token, error)
+}
+
+// SecretResolver defines the interface for looking up the client secret
+// each time an access token is requested, so that rotated secrets take
+// effect without rebuilding the token parameters.
+type SecretResolver interface {
+	// ResolveSecret returns the current client secret.
+	ResolveSecret(ctx context.Context) (string, error)
+}
+
+// SecretInvalidator is implemented by a SecretResolver that caches the client
+// secret, like those returned by CachingSecretResolver. When the token
+// endpoint rejects the secret as an invalid client, the cached secret is
+// invalidated, so that a rotated secret is resolved again on the next
+// request. A SecretResolver wrapping a caching one should implement it and
+// forward Invalidate.
+type SecretInvalidator interface {
+	// Invalidate discards the cached secret.
+	Invalidate()
+}
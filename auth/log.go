@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/fragment-dev/fragment-go/internal/redact"
+)
+
+// LogLevels sets the levels at which an AuthenticatedContext logs events.
+type LogLevels struct {
+	// The level of access tokens that were fetched.
+	TokenFetched slog.Level
+	// The level of access tokens that couldn't be fetched.
+	TokenFetchFailed slog.Level
+}
+
+// DefaultLogLevels logs fetched tokens at debug level and failures to fetch
+// them at warning level.
+func DefaultLogLevels() LogLevels {
+	return LogLevels{
+		TokenFetched:     slog.LevelDebug,
+		TokenFetchFailed: slog.LevelWarn,
+	}
+}
+
+// WithLogger sets the logger that token requests are logged to, at levels.
+// Tokens, Basic credentials and client secrets are redacted. By default,
+// nothing is logged.
+func WithLogger(logger *slog.Logger, levels LogLevels) Option {
+	return func(o *options) {
+		o.logger = redact.Logger(logger)
+		o.logLevels = levels
+	}
+}
+
+type loggingTokenSource struct {
+	source TokenSource
+	logger *slog.Logger
+	levels LogLevels
+}
+
+func (s *loggingTokenSource) Token() (*Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		s.logger.Log(context.Background(), s.levels.TokenFetchFailed, "Failed to fetch access token", "error", err)
+		return nil, err
+	}
+	s.logger.Log(context.Background(), s.levels.TokenFetched, "Fetched access token", "expires_at", token.ExpiresAt)
+	return token, nil
+}
+
+// logTokens returns source, logging the tokens it fetches if a logger is set.
+func (o *options) logTokens(source TokenSource) TokenSource {
+	if o.logger == nil {
+		return source
+	}
+	return &loggingTokenSource{source: source, logger: o.logger, levels: o.logLevels}
+}
@@ -0,0 +1,50 @@
+package auth
+
+import "golang.org/x/oauth2"
+
+type fromOAuth2TokenSource struct {
+	source oauth2.TokenSource
+}
+
+func (s fromOAuth2TokenSource) Token() (*Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &Token{
+		AccessToken: token.AccessToken,
+		ExpiresAt:   token.Expiry,
+	}, nil
+}
+
+// FromOAuth2 adapts an oauth2.TokenSource into a TokenSource.
+func FromOAuth2(source oauth2.TokenSource) TokenSource {
+	if ts, ok := source.(toOAuth2TokenSource); ok {
+		return ts.source
+	}
+	return fromOAuth2TokenSource{source}
+}
+
+type toOAuth2TokenSource struct {
+	source TokenSource
+}
+
+func (s toOAuth2TokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      token.ExpiresAt,
+	}, nil
+}
+
+// ToOAuth2 adapts a TokenSource into an oauth2.TokenSource.
+func ToOAuth2(source TokenSource) oauth2.TokenSource {
+	if ts, ok := source.(fromOAuth2TokenSource); ok {
+		return ts.source
+	}
+	return toOAuth2TokenSource{source}
+}
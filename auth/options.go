@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+type options struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	logger     *slog.Logger
+	logLevels  LogLevels
+}
+
+// Option configures an AuthenticatedContext.
+type Option func(*options)
+
+// WithHTTPClient sets the http.Client used to request access tokens. It is
+// also used by client.NewClient, and so by the generated queries, for
+// requests to the Fragment API.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for all requests made with
+// the AuthenticatedContext. It overrides the transport of WithHTTPClient.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// getHTTPClient returns the configured http.Client, or nil if neither
+// WithHTTPClient nor WithTransport was provided.
+func (o *options) getHTTPClient() *http.Client {
+	if o.transport == nil {
+		return o.httpClient
+	}
+	client := &http.Client{}
+	if o.httpClient != nil {
+		*client = *o.httpClient
+	}
+	client.Transport = o.transport
+	return client
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretResolverFunc adapts a function into a SecretResolver.
+type SecretResolverFunc func(ctx context.Context) (string, error)
+
+func (f SecretResolverFunc) ResolveSecret(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// EnvSecretResolver returns a SecretResolver that reads the client secret
+// from the named environment variable.
+func EnvSecretResolver(name string) SecretResolver {
+	return SecretResolverFunc(func(context.Context) (string, error) {
+		secret := os.Getenv(name)
+		if secret == "" {
+			return "", fmt.Errorf("The environment variable %s is not set", name)
+		}
+		return secret, nil
+	})
+}
+
+// FileSecretResolver returns a SecretResolver that reads the client secret
+// from the file at path, ignoring surrounding whitespace.
+func FileSecretResolver(path string) SecretResolver {
+	return SecretResolverFunc(func(context.Context) (string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		secret := strings.TrimSpace(string(data))
+		if secret == "" {
+			return "", fmt.Errorf("The secret file %s is empty", path)
+		}
+		return secret, nil
+	})
+}
+
+type cachingSecretResolver struct {
+	resolver SecretResolver
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu         sync.Mutex
+	secret     string
+	resolvedAt time.Time
+}
+
+// CachingSecretResolver returns a SecretResolver that caches the secret
+// returned by resolver for ttl. The cached secret is also discarded when the
+// token endpoint rejects it.
+func CachingSecretResolver(resolver SecretResolver, ttl time.Duration) SecretResolver {
+	return &cachingSecretResolver{resolver: resolver, ttl: ttl, now: time.Now}
+}
+
+func (r *cachingSecretResolver) ResolveSecret(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.secret != "" && r.now().Sub(r.resolvedAt) < r.ttl {
+		return r.secret, nil
+	}
+	secret, err := r.resolver.ResolveSecret(ctx)
+	if err != nil {
+		return "", err
+	}
+	r.secret, r.resolvedAt = secret, r.now()
+	return secret, nil
+}
+
+// Invalidate discards the cached secret.
+func (r *cachingSecretResolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secret = ""
+}
+
+type secretResolverParams interface {
+	GetSecretResolver() SecretResolver
+}
+
+// resolveClientSecret returns the client secret from the params' SecretResolver,
+// if it has one, and from GetClientSecret otherwise.
+func resolveClientSecret(ctx context.Context, params TokenParams) (string, SecretResolver, error) {
+	srp, ok := params.(secretResolverParams)
+	if !ok || srp.GetSecretResolver() == nil {
+		return params.GetClientSecret(), nil, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	resolver := srp.GetSecretResolver()
+	secret, err := resolver.ResolveSecret(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to resolve the client secret: %w", err)
+	}
+	return secret, resolver, nil
+}
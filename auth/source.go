@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type clientCredentialsTokenSource struct {
+	ctx    context.Context
+	params TokenParams
+	client *http.Client
+}
+
+func (s *clientCredentialsTokenSource) Token() (*Token, error) {
+	return GetToken(s.ctx, s.params, s.client)
+}
+
+// ClientCredentialsTokenSource returns a TokenSource that fetches a new access
+// token with the client_credentials grant on every call. If client is nil, a
+// default http.Client is used.
+func ClientCredentialsTokenSource(ctx context.Context, params TokenParams, client *http.Client) TokenSource {
+	return &clientCredentialsTokenSource{ctx: ctx, params: params, client: client}
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s staticTokenSource) Token() (*Token, error) {
+	return s.token, nil
+}
+
+// StaticTokenSource returns a TokenSource that always returns the same token.
+// This is useful in tests and for tokens vended outside of the SDK.
+func StaticTokenSource(token *Token) TokenSource {
+	return staticTokenSource{token}
+}
+
+type reuseTokenSource struct {
+	store  TokenStore
+	source TokenSource
+}
+
+func (s *reuseTokenSource) Token() (*Token, error) {
+	token, ok := s.store.Get()
+	if ok && !token.Expired(time.Now()) {
+		return token, nil
+	}
+	return s.store.Refresh(token, s.source.Token)
+}
+
+// ReuseTokenSource returns a TokenSource that returns token until it expires,
+// then fetches and caches a new one from source. Concurrent callers share a
+// single fetch. The token may be nil.
+func ReuseTokenSource(token *Token, source TokenSource) TokenSource {
+	if rs, ok := source.(*reuseTokenSource); ok {
+		source = rs.source
+	}
+	return &reuseTokenSource{store: NewTokenStore(token), source: source}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import "sync"
+
+type refreshCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+type tokenStore struct {
+	mu       sync.Mutex
+	token    *Token
+	inflight *refreshCall
+}
+
+// NewTokenStore returns a TokenStore holding the provided access token.
+// The token may be nil, in which case the first Refresh fetches one.
+func NewTokenStore(token *Token) TokenStore {
+	return &tokenStore{token: token}
+}
+
+func (ts *tokenStore) Get() (*Token, bool) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.token, ts.token != nil
+}
+
+func (ts *tokenStore) Set(token *Token) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.token = token
+}
+
+func (ts *tokenStore) Refresh(stale *Token, fetch func() (*Token, error)) (*Token, error) {
+	ts.mu.Lock()
+	// Another caller has already replaced the stale token.
+	if ts.token != nil && ts.token != stale {
+		token := ts.token
+		ts.mu.Unlock()
+		return token, nil
+	}
+	// Another caller is fetching a new token, so wait for it.
+	if call := ts.inflight; call != nil {
+		ts.mu.Unlock()
+		<-call.done
+		return call.token, call.err
+	}
+	call := &refreshCall{done: make(chan struct{})}
+	ts.inflight = call
+	ts.mu.Unlock()
+
+	call.token, call.err = fetch()
+
+	ts.mu.Lock()
+	if call.err == nil {
+		ts.token = call.token
+	}
+	ts.inflight = nil
+	ts.mu.Unlock()
+	close(call.done)
+
+	return call.token, call.err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -36,6 +37,10 @@ type GetTokenParams struct {
 	// The API URL for this token.
 	// Required: true
 	ApiUrl string `json:"api_url"`
+	// Looks up the client secret each time a token is requested. If set,
+	// it is used instead of ClientSecret.
+	// Required: false
+	SecretResolver SecretResolver `json:"-"`
 }
 
 func (gtp *GetTokenParams) GetClientId() string {
@@ -46,6 +51,10 @@ func (gtp *GetTokenParams) GetClientSecret() string {
 	return gtp.ClientSecret
 }
 
+func (gtp *GetTokenParams) GetSecretResolver() SecretResolver {
+	return gtp.SecretResolver
+}
+
 func (gtp *GetTokenParams) GetScope() string {
 	return gtp.Scope
 }
@@ -70,6 +79,11 @@ type oauth2Response struct {
 	ExpiresIn   int64  `json:"expires_in"`
 }
 
+type oauth2ErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
 // Token represents a Fragment API access token.
 type Token struct {
 	// The Access Token.
@@ -78,38 +92,93 @@ type Token struct {
 	ExpiresAt time.Time
 }
 
+// Expired reports whether the token has expired at the provided time.
+// A token with a zero ExpiresAt never expires.
+func (t *Token) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && now.After(t.ExpiresAt)
+}
+
 type authenticatedContext struct {
 	context.Context
+
+	store      TokenStore
+	source     TokenSource
+	httpClient *http.Client
+	clients    ClientCache
 }
 
 func (ac *authenticatedContext) GetTokenParams() TokenParams {
-	return ac.Value(TokenParamsContextKey).(*GetTokenParams)
+	return ac.Value(TokenParamsContextKey).(TokenParams)
 }
 
 func (ac *authenticatedContext) GetToken() (*Token, bool) {
-	token, ok := ac.Value(TokenContextKey).(*Token)
-	return token, ok
+	return ac.store.Get()
 }
 
 func (ac *authenticatedContext) SetToken(token *Token) {
-	ac.Context = context.WithValue(ac.Context, TokenContextKey, token)
+	ac.store.Set(token)
+}
+
+func (ac *authenticatedContext) GetTokenStore() TokenStore {
+	return ac.store
+}
+
+func (ac *authenticatedContext) GetTokenSource() TokenSource {
+	return ac.source
+}
+
+func (ac *authenticatedContext) GetClientCache() *ClientCache {
+	return &ac.clients
+}
+
+func (ac *authenticatedContext) GetHTTPClient() *http.Client {
+	return ac.httpClient
 }
 
 // GetAuthenticatedContext returns an AuthenticatedContext embedded with an access token.
-func GetAuthenticatedContext(ctx context.Context, params TokenParams) (AuthenticatedContext, error) {
+func GetAuthenticatedContext(ctx context.Context, params TokenParams, opts ...Option) (AuthenticatedContext, error) {
 	if invalidErr := params.IsValid(); invalidErr != nil {
 		return nil, invalidErr
 	}
 	if ctx == nil {
 		return nil, fmt.Errorf("You must provide a context to GetAuthenticatedContext")
 	}
-	token, err := GetToken(ctx, params, nil)
+	o := newOptions(opts)
+	httpClient := o.getHTTPClient()
+	source := o.logTokens(ClientCredentialsTokenSource(ctx, params, httpClient))
+	token, err := source.Token()
 	if err != nil {
 		return nil, err
 	}
-	authedContext := &authenticatedContext{context.WithValue(ctx, TokenParamsContextKey, params)}
-	authedContext.SetToken(token)
-	return authedContext, nil
+	return &authenticatedContext{
+		Context:    context.WithValue(ctx, TokenParamsContextKey, params),
+		store:      NewTokenStore(token),
+		source:     source,
+		httpClient: httpClient,
+	}, nil
+}
+
+// NewAuthenticatedContext returns an AuthenticatedContext for the API at apiUrl
+// whose access tokens are supplied by source.
+func NewAuthenticatedContext(ctx context.Context, apiUrl string, source TokenSource, opts ...Option) (AuthenticatedContext, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("You must provide a context to NewAuthenticatedContext")
+	}
+	if source == nil {
+		return nil, fmt.Errorf("You must provide a token source to NewAuthenticatedContext")
+	}
+	o := newOptions(opts)
+	source = o.logTokens(source)
+	token, err := source.Token()
+	if err != nil {
+		return nil, err
+	}
+	return &authenticatedContext{
+		Context:    context.WithValue(ctx, TokenParamsContextKey, &GetTokenParams{ApiUrl: apiUrl}),
+		store:      NewTokenStore(token),
+		source:     source,
+		httpClient: o.getHTTPClient(),
+	}, nil
 }
 
 // GetToken retrieves a fresh access token from the API.
@@ -118,10 +187,15 @@ func GetToken(ctx context.Context, params TokenParams, client *http.Client) (*To
 		return nil, err
 	}
 
+	secret, resolver, err := resolveClientSecret(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
 	var sb strings.Builder
 	sb.WriteString(params.GetClientId())
 	sb.WriteByte(':')
-	sb.WriteString(params.GetClientSecret())
+	sb.WriteString(secret)
 
 	encodedAuthUrl := base64.StdEncoding.EncodeToString([]byte(sb.String()))
 
@@ -147,15 +221,29 @@ func GetToken(ctx context.Context, params TokenParams, client *http.Client) (*To
 		client = &http.Client{}
 	}
 	resp, err := client.Do(req)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Received non-OK status")
-	}
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		tokenErr := &TokenError{StatusCode: resp.StatusCode, Body: body}
+		var errResult oauth2ErrorResponse
+		if json.Unmarshal(body, &errResult) == nil {
+			tokenErr.Code = errResult.Error
+			tokenErr.Description = errResult.ErrorDescription
+		}
+		// Don't keep serving a cached secret that has been rotated.
+		if invalidator, ok := resolver.(SecretInvalidator); ok && errors.Is(tokenErr, ErrInvalidClient) {
+			invalidator.Invalidate()
+		}
+		return nil, tokenErr
+	}
+
 	var result oauth2Response
 	if err := json.Unmarshal(body, &result); err != nil {
 		return nil, err
@@ -1,13 +1,20 @@
 package auth
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"golang.org/x/oauth2"
 )
 
 func TestGetAuthenticatedContext(t *testing.T) {
@@ -72,3 +79,293 @@ func TestGetAuthenticatedContext(t *testing.T) {
 		t.Errorf("Expected token to expire in 3600 seconds, got %s", token.ExpiresAt)
 	}
 }
+
+func TestTokenStoreRefreshSkipsReplacedToken(t *testing.T) {
+	stale := &Token{AccessToken: "stale"}
+	fresh := &Token{AccessToken: "fresh"}
+	store := NewTokenStore(fresh)
+
+	token, err := store.Refresh(stale, func() (*Token, error) {
+		t.Errorf("Expected Refresh not to fetch a token")
+		return nil, nil
+	})
+	if err != nil {
+		t.Errorf("Got error from Refresh: %s", err)
+	}
+	if token != fresh {
+		t.Errorf("Expected access token fresh, got %s", token.AccessToken)
+	}
+}
+
+func TestTokenStoreConcurrentRefresh(t *testing.T) {
+	stale := &Token{AccessToken: "stale"}
+	store := NewTokenStore(stale)
+
+	var fetches int32
+	started, release := make(chan struct{}), make(chan struct{})
+	fetch := func() (*Token, error) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return &Token{AccessToken: "fresh"}, nil
+	}
+
+	var wg sync.WaitGroup
+	refresh := func() {
+		defer wg.Done()
+		token, err := store.Refresh(stale, fetch)
+		if err != nil {
+			t.Errorf("Got error from Refresh: %s", err)
+			return
+		}
+		if token.AccessToken != "fresh" {
+			t.Errorf("Expected access token fresh, got %s", token.AccessToken)
+		}
+	}
+
+	// Start the other callers once the first fetch is in flight, and only let
+	// it finish once they've all started. Whether they wait for it or find
+	// the fresh token once it's stored, none of them fetches again.
+	wg.Add(1)
+	go refresh()
+	<-started
+	var waiting sync.WaitGroup
+	for i := 0; i < 49; i++ {
+		wg.Add(1)
+		waiting.Add(1)
+		go func() {
+			waiting.Done()
+			refresh()
+		}()
+	}
+	waiting.Wait()
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("Expected 1 fetch, got %d", n)
+	}
+}
+
+type countingTokenSource struct {
+	calls int
+}
+
+func (cts *countingTokenSource) Token() (*Token, error) {
+	cts.calls++
+	return &Token{AccessToken: "token", ExpiresAt: time.Now().Add(time.Hour)}, nil
+}
+
+func TestNewAuthenticatedContext(t *testing.T) {
+	source := StaticTokenSource(&Token{AccessToken: "static"})
+	authedContext, err := NewAuthenticatedContext(context.TODO(), "https://api.example.com/graphql", source)
+	if err != nil {
+		t.Errorf("Got error from NewAuthenticatedContext: %s", err)
+	}
+
+	token, ok := authedContext.GetToken()
+	if !ok {
+		t.Errorf("Failed to get token from context")
+	}
+	if token.AccessToken != "static" {
+		t.Errorf("Expected access token static, got %s", token.AccessToken)
+	}
+	if token.Expired(time.Now()) {
+		t.Errorf("Expected a token without ExpiresAt not to expire")
+	}
+	if apiUrl := authedContext.GetTokenParams().GetApiUrl(); apiUrl != "https://api.example.com/graphql" {
+		t.Errorf("Expected API URL https://api.example.com/graphql, got %s", apiUrl)
+	}
+}
+
+func TestReuseTokenSource(t *testing.T) {
+	source := &countingTokenSource{}
+	reuse := ReuseTokenSource(nil, source)
+	for i := 0; i < 3; i++ {
+		if _, err := reuse.Token(); err != nil {
+			t.Errorf("Got error from Token: %s", err)
+		}
+	}
+	if source.calls != 1 {
+		t.Errorf("Expected 1 call to the underlying source, got %d", source.calls)
+	}
+}
+
+func TestOAuth2Adapters(t *testing.T) {
+	expiresAt := time.Unix(1700000000, 0)
+	source := StaticTokenSource(&Token{AccessToken: "token", ExpiresAt: expiresAt})
+
+	oauth2Token, err := ToOAuth2(source).Token()
+	if err != nil {
+		t.Errorf("Got error from Token: %s", err)
+	}
+	if oauth2Token.AccessToken != "token" || oauth2Token.TokenType != "Bearer" || !oauth2Token.Expiry.Equal(expiresAt) {
+		t.Errorf("Unexpected oauth2 token %+v", oauth2Token)
+	}
+
+	token, err := FromOAuth2(oauth2.StaticTokenSource(oauth2Token)).Token()
+	if err != nil {
+		t.Errorf("Got error from Token: %s", err)
+	}
+	if token.AccessToken != "token" || !token.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Unexpected token %+v", token)
+	}
+}
+
+func TestGetTokenError(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		target error
+	}{
+		{http.StatusBadRequest, `{"error":"invalid_client","error_description":"bad secret"}`, ErrInvalidClient},
+		{http.StatusBadRequest, `{"error":"invalid_scope"}`, ErrInvalidScope},
+		{http.StatusServiceUnavailable, `upstream unavailable`, ErrAuthServerUnavailable},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		}))
+
+		_, err := GetToken(context.TODO(), MockTokenParams{server.URL}, nil)
+		server.Close()
+
+		var tokenErr *TokenError
+		if !errors.As(err, &tokenErr) {
+			t.Errorf("Expected a TokenError, got %v", err)
+			continue
+		}
+		if tokenErr.StatusCode != test.status {
+			t.Errorf("Expected status %d, got %d", test.status, tokenErr.StatusCode)
+		}
+		if string(tokenErr.Body) != test.body {
+			t.Errorf("Expected body %s, got %s", test.body, string(tokenErr.Body))
+		}
+		if !errors.Is(err, test.target) {
+			t.Errorf("Expected %v to match %v", err, test.target)
+		}
+	}
+}
+
+func TestUnauthorizedClientIsNotInvalidClient(t *testing.T) {
+	err := &TokenError{StatusCode: http.StatusBadRequest, Code: "unauthorized_client"}
+	if errors.Is(err, ErrInvalidClient) {
+		t.Errorf("Expected %v not to match %v", err, ErrInvalidClient)
+	}
+}
+
+// wrappingSecretResolver is a user's resolver wrapping a caching one.
+type wrappingSecretResolver struct {
+	SecretResolver
+}
+
+func (w wrappingSecretResolver) Invalidate() {
+	w.SecretResolver.(SecretInvalidator).Invalidate()
+}
+
+func TestGetTokenWithSecretResolver(t *testing.T) {
+	tests := []struct {
+		name string
+		wrap func(SecretResolver) SecretResolver
+	}{
+		{"caching", func(r SecretResolver) SecretResolver { return r }},
+		{"wrapped", func(r SecretResolver) SecretResolver { return wrappingSecretResolver{r} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := "first_secret"
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hb, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(r.Header.Get("Authorization"), "Basic "))
+				if err != nil {
+					t.Errorf("Failed to decode base64: %s", err)
+				}
+				if string(hb) != "test_client_id:"+secret {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"error":"invalid_client"}`))
+					return
+				}
+				w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+			}))
+			defer server.Close()
+
+			var resolves int
+			resolver := tt.wrap(CachingSecretResolver(SecretResolverFunc(func(context.Context) (string, error) {
+				resolves++
+				return secret, nil
+			}), time.Hour))
+			params := &GetTokenParams{
+				ClientId:       "test_client_id",
+				Scope:          "*",
+				AuthUrl:        server.URL + "/oauth2/token",
+				ApiUrl:         server.URL,
+				SecretResolver: resolver,
+			}
+
+			if _, err := GetToken(context.TODO(), params, nil); err != nil {
+				t.Errorf("Got error from GetToken: %s", err)
+			}
+
+			// Rotate the secret. The cached one is rejected once, then resolved again.
+			secret = "second_secret"
+			if _, err := GetToken(context.TODO(), params, nil); !errors.Is(err, ErrInvalidClient) {
+				t.Errorf("Expected ErrInvalidClient, got %v", err)
+			}
+			if _, err := GetToken(context.TODO(), params, nil); err != nil {
+				t.Errorf("Got error from GetToken: %s", err)
+			}
+			if resolves != 2 {
+				t.Errorf("Expected 2 resolves, got %d", resolves)
+			}
+		})
+	}
+}
+
+type failingTokenSource struct {
+	err error
+}
+
+func (fts failingTokenSource) Token() (*Token, error) {
+	return nil, fts.err
+}
+
+func TestGetAuthenticatedContextLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"secret_access_token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	_, err := GetAuthenticatedContext(context.TODO(), &GetTokenParams{
+		ClientId:     "client_id",
+		ClientSecret: "client_secret_value",
+		Scope:        "scope",
+		AuthUrl:      server.URL + "/oauth2/token",
+		ApiUrl:       server.URL,
+	}, WithLogger(logger, DefaultLogLevels()))
+	if err != nil {
+		t.Fatalf("Got error from GetAuthenticatedContext: %s", err)
+	}
+
+	// A source whose error echoes the request's credentials, as a proxy
+	// might, leaks them unless they're redacted.
+	leaky := failingTokenSource{errors.New("proxy rejected request with Authorization: Bearer secret_access_token")}
+	if _, err := NewAuthenticatedContext(context.TODO(), server.URL, leaky, WithLogger(logger, DefaultLogLevels())); err == nil {
+		t.Fatalf("Expected an error from NewAuthenticatedContext")
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Fetched access token") {
+		t.Errorf("Expected token request to be logged, got %s", output)
+	}
+	if !strings.Contains(output, "Failed to fetch access token") || !strings.Contains(output, "Bearer [REDACTED]") {
+		t.Errorf("Expected the failed token request to be logged with its credentials redacted, got %s", output)
+	}
+	for _, secret := range []string{"secret_access_token", "client_secret_value"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %s not to be logged, got %s", secret, output)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package client
+
+import (
+	"github.com/Khan/genqlient/graphql"
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+// cachedClientOf returns the client cached on ctx, creating it with newClient
+// on first use. Contexts without a ClientCache get a new client every time.
+func cachedClientOf(ctx auth.AuthenticatedContext, newClient func() (graphql.Client, error)) (graphql.Client, error) {
+	provider, ok := ctx.(auth.ClientCacheProvider)
+	if !ok {
+		return newClient()
+	}
+	client, err := provider.GetClientCache().Load(func() (any, error) {
+		return newClient()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client.(graphql.Client), nil
+}
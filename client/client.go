@@ -1,6 +1,11 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"math/rand"
 	"net/http"
 	"time"
 
@@ -12,7 +17,20 @@ type HttpClient struct {
 	*http.Client
 
 	auth.AuthenticatedContext
-	clock Clock
+	tokenStore  auth.TokenStore
+	tokenSource auth.TokenSource
+	clock       Clock
+	rand        func() float64
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	metrics     Metrics
+
+	logger             *slog.Logger
+	logLevels          LogLevels
+	logVariables       bool
+	sensitiveVariables []string
+	// The middleware chain requests are sent through.
+	doer Doer
 }
 
 type realClock struct{}
@@ -21,46 +39,209 @@ func (realClock) Now() time.Time {
 	return time.Now()
 }
 
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
 func getClock() Clock {
 	return &realClock{}
 }
 
-func newHttpClient(ctx auth.AuthenticatedContext, clock Clock) *HttpClient {
+func newHttpClient(ctx auth.AuthenticatedContext, clock Clock, opts ...Option) *HttpClient {
 	if clock == nil {
 		clock = getClock()
 	}
-	return &HttpClient{
-		Client:               &http.Client{},
+	o := newOptions(opts)
+	if o.metrics == nil {
+		o.metrics = NoopMetrics{}
+	}
+	c := &HttpClient{
+		Client:               o.getHTTPClient(httpClientOf(ctx)),
 		AuthenticatedContext: ctx,
+		tokenStore:           tokenStoreOf(ctx),
+		tokenSource:          tokenSourceOf(ctx),
 		clock:                clock,
+		rand:                 rand.Float64,
+		retryPolicy:          o.retryPolicy.withDefaults(),
+		rateLimiter:          o.rateLimiter,
+		metrics:              o.metrics,
+		logger:               o.logger,
+		logLevels:            o.logLevels,
+		logVariables:         o.logVariables,
+		sensitiveVariables:   o.sensitiveVariables,
 	}
+	// Registered middleware sees each request once, before it is measured,
+	// logged, retried, rate limited and authenticated.
+	middleware := append(o.middleware[:len(o.middleware):len(o.middleware)],
+		c.measure,
+		c.log,
+		c.retry,
+		c.rateLimit,
+		c.authenticate,
+		setClientHeader)
+	c.doer = chain(c.Client, middleware)
+	return c
 }
 
 func (c *HttpClient) Do(req *http.Request) (*http.Response, error) {
-	token, _ := c.AuthenticatedContext.GetToken()
-	// If the token has expired, get a new one.
-	if c.clock.Now().After(token.ExpiresAt) {
-		token, err := auth.GetToken(
-			c.AuthenticatedContext,
-			c.AuthenticatedContext.GetTokenParams(),
-			nil)
+	// Issue the request within the authenticated context, if a context hasn't been set.
+	if req.Context() == nil {
+		req = req.WithContext(c.AuthenticatedContext)
+	}
+	if err := bufferBody(req); err != nil {
+		return nil, err
+	}
+	return c.doer.Do(req)
+}
+
+// bufferBody reads the request body into memory, if it can't already be
+// re-read, so that the request can be replayed.
+func bufferBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// rewind returns a copy of req that can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
 		if err != nil {
 			return nil, err
 		}
+		next.Body = body
+	}
+	return next, nil
+}
+
+func newClient(ctx auth.AuthenticatedContext, opts []Option) (graphql.Client, error) {
+	tokenParams := ctx.GetTokenParams()
+	return &operationClient{graphql.NewClient(tokenParams.GetApiUrl(), newHttpClient(ctx, nil, opts...))}, nil
+}
 
-		c.AuthenticatedContext.SetToken(token)
+// NewClient returns the GraphQL client for the provided authenticated context.
+// Unless overridden by opts, requests use the context's http.Client.
+//
+// Without opts, the client is created once and cached on the context, so that
+// requests made with the context reuse its connections. With opts, a new client is returned on every call; use
+// WithOptions to cache a configured client.
+func NewClient(ctx auth.AuthenticatedContext, opts ...Option) (graphql.Client, error) {
+	if rc, ok := ctx.(*requestContext); ok {
+		// The request's context is passed to the client with each request.
+		ctx = rc.AuthenticatedContext
 	}
-	// Issue the request within the authenticated context, if a context hasn't been set.
-	if req.Context() == nil {
-		req = req.WithContext(c.AuthenticatedContext)
+	var base []Option
+	if cc, ok := ctx.(*configuredContext); ok {
+		base = cc.opts[:len(cc.opts):len(cc.opts)]
 	}
-	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
-	req.Header.Set("X-Fragment-Client", "go-client")
-	return c.Client.Do(req)
+	if len(opts) > 0 {
+		return newClient(ctx, append(base, opts...))
+	}
+	return cachedClientOf(ctx, func() (graphql.Client, error) {
+		return newClient(ctx, base)
+	})
 }
 
-// NewClient creates a new GraphQL client with the provided authenticated context.
-func NewClient(ctx auth.AuthenticatedContext) (graphql.Client, error) {
-	tokenParams := ctx.GetTokenParams()
-	return graphql.NewClient(tokenParams.GetApiUrl(), newHttpClient(ctx, nil)), nil
+type configuredContext struct {
+	auth.AuthenticatedContext
+
+	opts    []Option
+	clients auth.ClientCache
+}
+
+func (cc *configuredContext) GetClientCache() *auth.ClientCache {
+	return &cc.clients
+}
+
+func (cc *configuredContext) GetTokenStore() auth.TokenStore {
+	return tokenStoreOf(cc.AuthenticatedContext)
+}
+
+func (cc *configuredContext) GetTokenSource() auth.TokenSource {
+	return tokenSourceOf(cc.AuthenticatedContext)
+}
+
+func (cc *configuredContext) GetHTTPClient() *http.Client {
+	return httpClientOf(cc.AuthenticatedContext)
+}
+
+// WithOptions returns a copy of ctx whose GraphQL client is configured with opts.
+// The copy shares the access token of ctx. Pass it to the generated queries to
+// make requests with the configured client.
+func WithOptions(ctx auth.AuthenticatedContext, opts ...Option) auth.AuthenticatedContext {
+	if cc, ok := ctx.(*configuredContext); ok {
+		ctx = cc.AuthenticatedContext
+		opts = append(cc.opts[:len(cc.opts):len(cc.opts)], opts...)
+	}
+	return &configuredContext{AuthenticatedContext: ctx, opts: opts}
+}
+
+type requestContext struct {
+	auth.AuthenticatedContext
+
+	ctx context.Context
+}
+
+func (rc *requestContext) Deadline() (time.Time, bool) {
+	deadline, ok := rc.AuthenticatedContext.Deadline()
+	if reqDeadline, reqOk := rc.ctx.Deadline(); reqOk && (!ok || reqDeadline.Before(deadline)) {
+		return reqDeadline, true
+	}
+	return deadline, ok
+}
+
+func (rc *requestContext) Done() <-chan struct{} {
+	return rc.ctx.Done()
+}
+
+func (rc *requestContext) Err() error {
+	return rc.ctx.Err()
+}
+
+// Value looks key up in the request's context first, so that values such as
+// trace spans reach the request, then in the authenticated context.
+func (rc *requestContext) Value(key any) any {
+	if value := rc.ctx.Value(key); value != nil {
+		return value
+	}
+	return rc.AuthenticatedContext.Value(key)
+}
+
+func (rc *requestContext) GetTokenStore() auth.TokenStore {
+	return tokenStoreOf(rc.AuthenticatedContext)
+}
+
+func (rc *requestContext) GetTokenSource() auth.TokenSource {
+	return tokenSourceOf(rc.AuthenticatedContext)
+}
+
+func (rc *requestContext) GetHTTPClient() *http.Client {
+	return httpClientOf(rc.AuthenticatedContext)
+}
+
+// WithRequestContext returns a copy of ctx that is also done when reqCtx is,
+// so that requests made with it are cancelled by either. The copy shares the
+// access token and GraphQL client of ctx. Call cancel once the requests are
+// made to release its resources.
+func WithRequestContext(ctx auth.AuthenticatedContext, reqCtx context.Context) (auth.AuthenticatedContext, context.CancelFunc) {
+	if reqCtx == nil || reqCtx.Done() == nil {
+		return ctx, func() {}
+	}
+	merged, cancel := context.WithCancel(reqCtx)
+	stop := context.AfterFunc(ctx, cancel)
+	return &requestContext{AuthenticatedContext: ctx, ctx: merged}, func() {
+		stop()
+		cancel()
+	}
 }
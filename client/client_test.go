@@ -2,12 +2,19 @@ package client
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/Khan/genqlient/graphql"
 	"github.com/fragment-dev/fragment-go/auth"
 )
 
@@ -25,15 +32,29 @@ func (mockAlwaysBeforeClock) Now() time.Time {
 
 type mockAuthContext struct {
 	context.Context
+
+	store   auth.TokenStore
+	source  auth.TokenSource
+	clients auth.ClientCache
+}
+
+func (mac *mockAuthContext) GetClientCache() *auth.ClientCache {
+	return &mac.clients
 }
 
 func (mac *mockAuthContext) GetToken() (*auth.Token, bool) {
-	token, ok := mac.Context.Value(auth.TokenContextKey).(*auth.Token)
-	return token, ok
+	return mac.store.Get()
 }
 
 func (mac *mockAuthContext) SetToken(token *auth.Token) {
-	mac.Context = context.WithValue(mac.Context, auth.TokenContextKey, token)
+	mac.store.Set(token)
+}
+
+func (mac *mockAuthContext) GetTokenSource() auth.TokenSource {
+	if mac.source != nil {
+		return mac.source
+	}
+	return auth.ClientCredentialsTokenSource(mac, mac.GetTokenParams(), nil)
 }
 
 func (mac *mockAuthContext) GetTokenParams() auth.TokenParams {
@@ -41,14 +62,38 @@ func (mac *mockAuthContext) GetTokenParams() auth.TokenParams {
 }
 
 func getMockedAuthenticatedContext(serverUrl string) *mockAuthContext {
-	mac := &mockAuthContext{
-		context.WithValue(
-			context.TODO(), auth.TokenParamsContextKey, &auth.MockTokenParams{serverUrl})}
-	mac.SetToken(&auth.Token{
-		AccessToken: "access_token",
-		ExpiresAt:   time.Unix(0, 0),
-	})
-	return mac
+	return &mockAuthContext{
+		Context: context.WithValue(
+			context.TODO(), auth.TokenParamsContextKey, &auth.MockTokenParams{ServerUrl: serverUrl}),
+		store: auth.NewTokenStore(&auth.Token{
+			AccessToken: "access_token",
+			ExpiresAt:   time.Unix(0, 0),
+		}),
+	}
+}
+
+// mockBareAuthContext implements only AuthenticatedContext, without any of
+// the optional interfaces of the auth package.
+type mockBareAuthContext struct {
+	context.Context
+	mu    sync.Mutex
+	token *auth.Token
+}
+
+func (mbc *mockBareAuthContext) GetToken() (*auth.Token, bool) {
+	mbc.mu.Lock()
+	defer mbc.mu.Unlock()
+	return mbc.token, mbc.token != nil
+}
+
+func (mbc *mockBareAuthContext) SetToken(token *auth.Token) {
+	mbc.mu.Lock()
+	defer mbc.mu.Unlock()
+	mbc.token = token
+}
+
+func (mbc *mockBareAuthContext) GetTokenParams() auth.TokenParams {
+	return mbc.Context.Value(auth.TokenParamsContextKey).(*auth.MockTokenParams)
 }
 
 func getMockServer(t_ *testing.T) *httptest.Server {
@@ -98,3 +143,327 @@ func TestTokenSkipRefresh(t *testing.T) {
 		t.Errorf("Got error from Do: %s", err)
 	}
 }
+
+func TestConcurrentTokenRefresh(t *testing.T) {
+	var tokenRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+			atomic.AddInt32(&tokenRequests, 1)
+			w.Write([]byte(`{"access_token":"new_access_token","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new_access_token" {
+			t.Errorf("Expected refreshed token, got %s", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Errorf("Failed to parse server URL: %s", err)
+	}
+
+	httpClient := newHttpClient(getMockedAuthenticatedContext(server.URL), &mockAlwaysAfterClock{})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := httpClient.Do(&http.Request{URL: serverURL, Header: http.Header{}})
+			if err != nil {
+				t.Errorf("Got error from Do: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&tokenRequests); n != 1 {
+		t.Errorf("Expected 1 token request, got %d", n)
+	}
+}
+
+func TestBareAuthenticatedContext(t *testing.T) {
+	var tokenRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+			atomic.AddInt32(&tokenRequests, 1)
+			w.Write([]byte(`{"access_token":"new_access_token","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new_access_token" {
+			t.Errorf("Expected refreshed token, got %s", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	mbc := &mockBareAuthContext{
+		Context: context.WithValue(context.TODO(), auth.TokenParamsContextKey, &auth.MockTokenParams{ServerUrl: server.URL}),
+		token:   &auth.Token{AccessToken: "access_token", ExpiresAt: time.Unix(0, 0)},
+	}
+	serverURL, _ := url.Parse(server.URL)
+	httpClient := newHttpClient(mbc, &mockAlwaysAfterClock{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := httpClient.Do(&http.Request{URL: serverURL, Header: http.Header{}})
+			if err != nil {
+				t.Errorf("Got error from Do: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&tokenRequests); n != 1 {
+		t.Errorf("Expected 1 token request, got %d", n)
+	}
+	if token, _ := mbc.GetToken(); token.AccessToken != "new_access_token" {
+		t.Errorf("Expected the refreshed token to be set on the context, got %s", token.AccessToken)
+	}
+}
+
+func getRevokingMockServer(t *testing.T, apiRequests *int32, tokenRequests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+			atomic.AddInt32(tokenRequests, 1)
+			w.Write([]byte(`{"access_token":"new_access_token","expires_in":3600}`))
+			return
+		}
+		atomic.AddInt32(apiRequests, 1)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read request body: %s", err)
+		}
+		if string(body) != `{"query":"query"}` {
+			t.Errorf("Expected the request body to be replayed, got %s", string(body))
+		}
+		// Only the refreshed token is accepted.
+		if r.Header.Get("Authorization") != "Bearer new_access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestUnauthorizedRetry(t *testing.T) {
+	var apiRequests, tokenRequests int32
+	server := getRevokingMockServer(t, &apiRequests, &tokenRequests)
+	defer server.Close()
+
+	mac := getMockedAuthenticatedContext(server.URL)
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"query":"query"}`))
+	if err != nil {
+		t.Errorf("Failed to create request: %s", err)
+	}
+	// Force the body to be buffered by Do.
+	req.GetBody = nil
+
+	resp, err := newHttpClient(mac, &mockAlwaysBeforeClock{}).Do(req)
+	if err != nil {
+		t.Errorf("Got error from Do: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if apiRequests != 2 || tokenRequests != 1 {
+		t.Errorf("Expected 2 API requests and 1 token request, got %d and %d", apiRequests, tokenRequests)
+	}
+	token, _ := mac.GetToken()
+	if token.AccessToken != "new_access_token" {
+		t.Errorf("Expected access token new_access_token, got %s", token.AccessToken)
+	}
+}
+
+func TestUnauthorizedRetryOnce(t *testing.T) {
+	var apiRequests, tokenRequests int32
+	server := getRevokingMockServer(t, &apiRequests, &tokenRequests)
+	defer server.Close()
+
+	mac := getMockedAuthenticatedContext(server.URL)
+	// The token endpoint keeps vending a token the API rejects.
+	mac.source = auth.StaticTokenSource(&auth.Token{AccessToken: "revoked_access_token"})
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"query":"query"}`))
+	if err != nil {
+		t.Errorf("Failed to create request: %s", err)
+	}
+
+	resp, err := newHttpClient(mac, &mockAlwaysBeforeClock{}).Do(req)
+	if err != nil {
+		t.Errorf("Got error from Do: %s", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", resp.StatusCode)
+	}
+	if apiRequests != 2 {
+		t.Errorf("Expected 2 API requests, got %d", apiRequests)
+	}
+}
+
+type recordingTransport struct {
+	requests int32
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&rt.requests, 1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	contextTransport := &recordingTransport{}
+	ctx, err := auth.NewAuthenticatedContext(
+		context.TODO(),
+		server.URL,
+		auth.StaticTokenSource(&auth.Token{AccessToken: "access_token"}),
+		auth.WithTransport(contextTransport))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+
+	client, _ := NewClient(ctx)
+	if err := client.MakeRequest(ctx, &graphql.Request{OpName: "GetWorkspace"}, &graphql.Response{}); err != nil {
+		t.Errorf("Got error from MakeRequest: %s", err)
+	}
+	if contextTransport.requests != 1 {
+		t.Errorf("Expected the context's transport to be used, got %d requests", contextTransport.requests)
+	}
+
+	clientTransport := &recordingTransport{}
+	client, _ = NewClient(ctx, WithTransport(clientTransport))
+	if err := client.MakeRequest(ctx, &graphql.Request{OpName: "GetWorkspace"}, &graphql.Response{}); err != nil {
+		t.Errorf("Got error from MakeRequest: %s", err)
+	}
+	if contextTransport.requests != 1 || clientTransport.requests != 1 {
+		t.Errorf("Expected the client's transport to be used, got %d and %d requests",
+			contextTransport.requests, clientTransport.requests)
+	}
+
+	configured := WithOptions(ctx, WithTransport(clientTransport))
+	client, _ = NewClient(configured)
+	if err := client.MakeRequest(configured, &graphql.Request{OpName: "GetWorkspace"}, &graphql.Response{}); err != nil {
+		t.Errorf("Got error from MakeRequest: %s", err)
+	}
+	if contextTransport.requests != 1 || clientTransport.requests != 2 {
+		t.Errorf("Expected the configured context's transport to be used, got %d and %d requests",
+			contextTransport.requests, clientTransport.requests)
+	}
+}
+
+func TestNewClientIsCached(t *testing.T) {
+	ctx := getMockedAuthenticatedContext("https://api.example.com")
+
+	first, _ := NewClient(ctx)
+	second, _ := NewClient(ctx)
+	if first != second {
+		t.Errorf("Expected NewClient to return the cached client")
+	}
+
+	configured := WithOptions(ctx, WithTransport(&recordingTransport{}))
+	third, _ := NewClient(configured)
+	fourth, _ := NewClient(configured)
+	if third == first || third != fourth {
+		t.Errorf("Expected the configured context to cache its own client")
+	}
+
+	if fifth, _ := NewClient(ctx, WithTransport(&recordingTransport{})); fifth == first {
+		t.Errorf("Expected NewClient with options to return a new client")
+	}
+}
+
+func TestNewClientCacheIsFreedWithContext(t *testing.T) {
+	bare := &mockBareAuthContext{
+		Context: context.WithValue(context.TODO(), auth.TokenParamsContextKey, &auth.MockTokenParams{}),
+	}
+	if first, _ := NewClient(bare); first == nil {
+		t.Fatalf("Expected a client for a context without a cache")
+	} else if second, _ := NewClient(bare); second == first {
+		t.Errorf("Expected a new client for a context without a cache")
+	}
+
+	// The context and its client reference each other, and a cycle with a
+	// finalizer isn't freed, so watch a value held only by the context.
+	type sentinelKey struct{}
+	sentinel := &[64]byte{}
+	freed := make(chan struct{})
+	runtime.SetFinalizer(sentinel, func(*[64]byte) { close(freed) })
+	func() {
+		ctx, err := auth.NewAuthenticatedContext(
+			context.WithValue(context.Background(), sentinelKey{}, sentinel),
+			"https://api.example.com",
+			auth.StaticTokenSource(&auth.Token{AccessToken: "access_token"}))
+		if err != nil {
+			t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+		}
+		NewClient(WithOptions(ctx))
+		NewClient(ctx)
+	}()
+	sentinel = nil
+	for deadline := time.Now().Add(time.Second); ; {
+		runtime.GC()
+		select {
+		case <-freed:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the context and its client to be freed")
+		}
+	}
+}
+
+func TestWithRequestContext(t *testing.T) {
+	received, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, err := auth.NewAuthenticatedContext(
+		context.TODO(), server.URL, auth.StaticTokenSource(&auth.Token{AccessToken: "access_token"}))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+	type requestIdKey struct{}
+	reqCtx, cancelReq := context.WithCancel(context.WithValue(context.TODO(), requestIdKey{}, "request-id"))
+	requestCtx, cancel := WithRequestContext(ctx, reqCtx)
+	defer cancel()
+
+	if requestCtx.Value(requestIdKey{}) != "request-id" {
+		t.Errorf("Expected the values of the request's context")
+	}
+	if requestCtx.Value(auth.TokenParamsContextKey) == nil {
+		t.Errorf("Expected the values of the authenticated context")
+	}
+
+	first, _ := NewClient(ctx)
+	client, _ := NewClient(requestCtx)
+	if client != first {
+		t.Errorf("Expected the request context to share the client of its context")
+	}
+
+	go func() {
+		<-received
+		cancelReq()
+	}()
+	err = client.MakeRequest(requestCtx, &graphql.Request{OpName: "GetWorkspace"}, &graphql.Response{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the request to be cancelled, got %v", err)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("Expected the authenticated context not to be cancelled, got %v", ctx.Err())
+	}
+}
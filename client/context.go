@@ -0,0 +1,67 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+// tokenStoreOf returns the TokenStore of ctx, if it provides one, or a store
+// backed by its GetToken and SetToken methods otherwise.
+func tokenStoreOf(ctx auth.AuthenticatedContext) auth.TokenStore {
+	if provider, ok := ctx.(auth.TokenStoreProvider); ok {
+		return provider.GetTokenStore()
+	}
+	return &contextTokenStore{ctx: ctx}
+}
+
+// tokenSourceOf returns the TokenSource of ctx, if it provides one, or a
+// source using the client_credentials grant with its token parameters
+// otherwise.
+func tokenSourceOf(ctx auth.AuthenticatedContext) auth.TokenSource {
+	if provider, ok := ctx.(auth.TokenSourceProvider); ok {
+		return provider.GetTokenSource()
+	}
+	return auth.ClientCredentialsTokenSource(ctx, ctx.GetTokenParams(), httpClientOf(ctx))
+}
+
+// httpClientOf returns the http.Client of ctx, if it provides one, or nil
+// otherwise.
+func httpClientOf(ctx auth.AuthenticatedContext) *http.Client {
+	if provider, ok := ctx.(auth.HTTPClientProvider); ok {
+		return provider.GetHTTPClient()
+	}
+	return nil
+}
+
+// contextTokenStore is a TokenStore for an AuthenticatedContext that doesn't
+// provide its own. Refreshes through the same store are serialized, so that
+// concurrent callers share a single fetch.
+type contextTokenStore struct {
+	ctx auth.AuthenticatedContext
+	mu  sync.Mutex
+}
+
+func (s *contextTokenStore) Get() (*auth.Token, bool) {
+	return s.ctx.GetToken()
+}
+
+func (s *contextTokenStore) Set(token *auth.Token) {
+	s.ctx.SetToken(token)
+}
+
+func (s *contextTokenStore) Refresh(stale *auth.Token, fetch func() (*auth.Token, error)) (*auth.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Another caller has already replaced the stale token.
+	if token, ok := s.ctx.GetToken(); ok && token != stale {
+		return token, nil
+	}
+	token, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	s.ctx.SetToken(token)
+	return token, nil
+}
@@ -5,3 +5,20 @@ import "time"
 type Clock interface {
 	Now() time.Time
 }
+
+// AfterClock is implemented by a Clock that also controls waiting, so that
+// tests can advance retries, rate limiting and token refreshes without
+// sleeping. Clocks that don't implement it wait with time.After.
+type AfterClock interface {
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(time.Duration) <-chan time.Time
+}
+
+// after waits for d with clock's After, if it has one.
+func after(clock Clock, d time.Duration) <-chan time.Time {
+	if a, ok := clock.(AfterClock); ok {
+		return a.After(d)
+	}
+	return time.After(d)
+}
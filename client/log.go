@@ -0,0 +1,151 @@
+package client
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/fragment-dev/fragment-go/internal/redact"
+)
+
+// LogLevels sets the levels at which a client logs events.
+type LogLevels struct {
+	// The level of operations that succeeded.
+	Operation slog.Level
+	// The level of operations that failed, or returned a Fragment error.
+	OperationFailed slog.Level
+	// The level of retried attempts.
+	Retry slog.Level
+	// The level of token refreshes made while sending operations.
+	TokenRefresh slog.Level
+}
+
+// DefaultLogLevels logs successful operations at debug level, retries and
+// token refreshes at info level, and failed operations at warning level.
+func DefaultLogLevels() LogLevels {
+	return LogLevels{
+		Operation:       slog.LevelDebug,
+		OperationFailed: slog.LevelWarn,
+		Retry:           slog.LevelInfo,
+		TokenRefresh:    slog.LevelInfo,
+	}
+}
+
+// WithLogger sets the logger that operations are logged to, at levels.
+// Tokens, Basic credentials and client secrets are redacted. By default,
+// nothing is logged.
+func WithLogger(logger *slog.Logger, levels LogLevels) Option {
+	return func(o *options) {
+		o.logger = redact.Logger(logger)
+		o.logLevels = levels
+	}
+}
+
+// WithVariableLogging logs the variables of every operation at debug level,
+// if a logger is set with WithLogger. The values of variables and input
+// fields named sensitive, at any depth, are masked.
+func WithVariableLogging(sensitive ...string) Option {
+	return func(o *options) {
+		o.logVariables = true
+		o.sensitiveVariables = append(o.sensitiveVariables, sensitive...)
+	}
+}
+
+// maskVariables returns variables with the values of sensitive fields masked.
+func maskVariables(variables interface{}, sensitive map[string]bool) interface{} {
+	// The generated queries pass their variables as structs, so read them
+	// through their JSON encoding.
+	encoded, err := json.Marshal(variables)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil
+	}
+	return mask(decoded, sensitive)
+}
+
+func mask(value interface{}, sensitive map[string]bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if sensitive[key] || redact.IsSensitiveKey(key) {
+				value[key] = redact.Redacted
+			} else {
+				value[key] = mask(field, sensitive)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = mask(item, sensitive)
+		}
+	}
+	return value
+}
+
+// log logs every operation sent through it, with its retries and token
+// refreshes, to the client's logger.
+func (c *HttpClient) log(next Doer) Doer {
+	if c.logger == nil {
+		return next
+	}
+	sensitive := map[string]bool{}
+	for _, name := range c.sensitiveVariables {
+		sensitive[name] = true
+	}
+
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		logger := c.logger
+		if op, ok := OperationFromContext(ctx); ok {
+			logger = logger.With("operation", op.OpName)
+			if c.logVariables {
+				logger.DebugContext(ctx, "Sending operation", "variables", maskVariables(op.Variables, sensitive))
+			}
+		}
+		ctx = WithClientTrace(ctx, &ClientTrace{
+			TokenRefreshStart: func() {
+				logger.Log(ctx, c.logLevels.TokenRefresh, "Refreshing access token")
+			},
+			TokenRefreshDone: func(err error) {
+				if err != nil {
+					logger.Log(ctx, c.logLevels.OperationFailed, "Failed to refresh access token", "error", err)
+				}
+			},
+			Retry: func(attempt int, wait time.Duration) {
+				logger.Log(ctx, c.logLevels.Retry, "Retrying operation", "attempt", attempt, "wait", wait)
+			},
+		})
+
+		start := c.clock.Now()
+		resp, err := next.Do(req.WithContext(ctx))
+		duration := c.clock.Now().Sub(start)
+		if err != nil {
+			logger.Log(ctx, c.logLevels.OperationFailed, "Operation failed", "duration", duration, "error", err)
+			return resp, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			logger.Log(ctx, c.logLevels.OperationFailed, "Operation failed", "duration", duration, "status", resp.StatusCode)
+			return resp, err
+		}
+		if !logger.Enabled(ctx, c.logLevels.Operation) && !logger.Enabled(ctx, c.logLevels.OperationFailed) {
+			// Don't read the body for a summary that isn't logged.
+			return resp, err
+		}
+		result, readErr := ReadOperationResult(resp)
+		if readErr != nil {
+			logger.Log(ctx, c.logLevels.OperationFailed, "Operation failed", "duration", duration, "error", readErr)
+			return resp, err
+		}
+		if result.Code != "" {
+			logger.Log(ctx, c.logLevels.OperationFailed, "Operation returned an error",
+				"duration", duration, "typename", result.Typename, "code", result.Code)
+			return resp, err
+		}
+		logger.Log(ctx, c.logLevels.Operation, "Operation succeeded",
+			"duration", duration, "typename", result.Typename, "is_ik_replay", result.IsIkReplay)
+		return resp, err
+	})
+}
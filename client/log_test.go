@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+func TestLogger(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data":{"addLedgerEntry":{"__typename":"AddLedgerEntryResult","isIkReplay":true}}}`))
+	}))
+	defer server.Close()
+
+	ctx, err := auth.NewAuthenticatedContext(
+		context.TODO(), server.URL, auth.StaticTokenSource(&auth.Token{AccessToken: "secret_access_token"}))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, _ := NewClient(ctx,
+		WithLogger(logger, DefaultLogLevels()),
+		WithVariableLogging("amount"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}))
+
+	err = client.MakeRequest(ctx, &graphql.Request{
+		OpName: "AddLedgerEntry",
+		Query:  "mutation AddLedgerEntry ($ik: SafeString!, $parameters: JSON!) { addLedgerEntry(ik: $ik, entry: {parameters: $parameters}) { __typename } }",
+		Variables: map[string]interface{}{
+			"ik":         "entry-ik",
+			"parameters": map[string]string{"amount": "12345", "user_id": "user-1"},
+		},
+	}, &graphql.Response{})
+	if err != nil {
+		t.Fatalf("Got error from MakeRequest: %s", err)
+	}
+
+	output := buf.String()
+	for _, expected := range []string{
+		"Sending operation",
+		"user-1",
+		"Retrying operation",
+		"Operation succeeded",
+		"operation=AddLedgerEntry",
+		"is_ik_replay=true",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected log to contain %s, got %s", expected, output)
+		}
+	}
+	for _, secret := range []string{"12345", "secret_access_token"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %s to be redacted from %s", secret, output)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestMetrics describes a completed GraphQL operation.
+type RequestMetrics struct {
+	// The name of the operation, such as AddLedgerEntry.
+	Operation string
+	// The time taken by the operation, including retries.
+	Duration time.Duration
+	// The HTTP status of the final response, or 0 if no response was received.
+	StatusCode int
+	// The code of the error returned by Fragment, if any.
+	Code string
+	// The error that prevented a response, if any.
+	Err error
+	// The number of times the operation was retried.
+	Retries int
+	// Whether a mutation was a replay of an earlier request with the same ik.
+	IsIkReplay bool
+}
+
+// Metrics records measurements of the requests made by a client.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest records a completed operation.
+	ObserveRequest(RequestMetrics)
+	// ObserveTokenRefresh records a token refresh made while sending an
+	// operation, with the error that caused it to fail, if any.
+	ObserveTokenRefresh(operation string, err error)
+}
+
+// NoopMetrics discards all measurements. It is the default Metrics of a client.
+type NoopMetrics struct{}
+
+func (NoopMetrics) ObserveRequest(RequestMetrics) {}
+
+func (NoopMetrics) ObserveTokenRefresh(string, error) {}
+
+// WithMetrics sets the Metrics that requests are recorded to.
+func WithMetrics(metrics Metrics) Option {
+	return func(o *options) {
+		o.metrics = metrics
+	}
+}
+
+// measure records every operation sent through it to the client's Metrics.
+func (c *HttpClient) measure(next Doer) Doer {
+	if _, ok := c.metrics.(NoopMetrics); ok {
+		return next
+	}
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		m := RequestMetrics{}
+		if op, ok := OperationFromContext(req.Context()); ok {
+			m.Operation = op.OpName
+		}
+		ctx := WithClientTrace(req.Context(), &ClientTrace{
+			TokenRefreshDone: func(err error) {
+				c.metrics.ObserveTokenRefresh(m.Operation, err)
+			},
+			Retry: func(int, time.Duration) {
+				m.Retries++
+			},
+		})
+
+		start := c.clock.Now()
+		resp, err := next.Do(req.WithContext(ctx))
+		m.Duration = c.clock.Now().Sub(start)
+		m.Err = err
+		if resp != nil {
+			m.StatusCode = resp.StatusCode
+			if resp.StatusCode == http.StatusOK {
+				if result, err := ReadOperationResult(resp); err == nil {
+					m.Code = result.Code
+					m.IsIkReplay = result.IsIkReplay
+				}
+			}
+		}
+		c.metrics.ObserveRequest(m)
+		return resp, err
+	})
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+// Doer sends an HTTP request. *http.Client implements it.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function into a Doer.
+type DoerFunc func(*http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a Doer to observe or modify the requests sent through it.
+// The GraphQL operation being made, including its name and variables, is
+// available from OperationFromContext(req.Context()).
+type Middleware func(next Doer) Doer
+
+// WithMiddleware registers middleware on the client. The first middleware
+// registered is the outermost. Registered middleware runs before the built-in
+// retry, rate limiting and authentication middleware, so it sees every
+// request once and never sees the access token.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// chain wraps doer in middleware, with the first middleware outermost.
+func chain(doer Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		doer = middleware[i](doer)
+	}
+	return doer
+}
+
+// setClientHeader identifies the SDK to the Fragment API.
+func setClientHeader(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		req.Header.Set("X-Fragment-Client", "go-client")
+		return next.Do(req)
+	})
+}
+
+// authenticate sends requests with the access token, refreshing it if it
+// has expired or is rejected.
+func (c *HttpClient) authenticate(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		token, ok := c.tokenStore.Get()
+		// If the token has expired, get a new one. Concurrent requests share a single refresh.
+		if !ok || token.Expired(c.clock.Now()) {
+			var err error
+			token, err = c.refreshToken(req, token)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Set the token on a copy, so that it doesn't leak to outer middleware.
+		authed := req.Clone(req.Context())
+		authed.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		resp, err := next.Do(authed)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+
+		// The token was revoked or rotated before it expired. Replace it and replay the request once.
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		token, err = c.refreshToken(req, token)
+		if err != nil {
+			return nil, err
+		}
+		replay, err := rewind(req)
+		if err != nil {
+			return nil, err
+		}
+		replay.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		return next.Do(replay)
+	})
+}
+
+// refreshToken replaces the stale token, reporting the refresh to the
+// ClientTrace of req.
+func (c *HttpClient) refreshToken(req *http.Request, stale *auth.Token) (*auth.Token, error) {
+	trace := ContextClientTrace(req.Context())
+	trace.tokenRefreshStart()
+	token, err := c.tokenStore.Refresh(stale, c.tokenSource.Token)
+	trace.tokenRefreshDone(err)
+	return token, err
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") != "request-1" {
+			t.Errorf("Expected X-Request-Id request-1, got %s", r.Header.Get("X-Request-Id"))
+		}
+		if r.Header.Get("Authorization") != "Bearer access_token" {
+			t.Errorf("Expected Authorization Bearer access_token, got %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Fragment-Client") != "go-client" {
+			t.Errorf("Expected X-Fragment-Client go-client, got %s", r.Header.Get("X-Fragment-Client"))
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer server.Close()
+
+	ctx, err := auth.NewAuthenticatedContext(
+		context.TODO(), server.URL, auth.StaticTokenSource(&auth.Token{AccessToken: "access_token"}))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				op, ok := OperationFromContext(req.Context())
+				if !ok || op.OpName != "GetLedger" {
+					t.Errorf("Expected operation GetLedger, got %+v", op)
+				}
+				if vars, _ := op.Variables.(map[string]string); vars["ik"] != "ledger-ik" {
+					t.Errorf("Expected variable ik ledger-ik, got %+v", op.Variables)
+				}
+				resp, err := next.Do(req)
+				if req.Header.Get("Authorization") != "" {
+					t.Errorf("Expected middleware not to see the access token")
+				}
+				return resp, err
+			})
+		}
+	}
+	setRequestId := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Request-Id", "request-1")
+			return next.Do(req)
+		})
+	}
+
+	client, _ := NewClient(ctx, WithMiddleware(record("first"), setRequestId), WithMiddleware(record("second")))
+	err = client.MakeRequest(ctx, &graphql.Request{
+		OpName:    "GetLedger",
+		Query:     "query GetLedger ($ik: SafeString!) { ledger(ledger: {ik:$ik}) { id } }",
+		Variables: map[string]string{"ik": "ledger-ik"},
+	}, &graphql.Response{})
+	if err != nil {
+		t.Errorf("Got error from MakeRequest: %s", err)
+	}
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Errorf("Expected middleware to run in order, got %v", calls)
+	}
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+type operationContextKey struct{}
+
+// OperationFromContext returns the GraphQL request being made with ctx. It is
+// set on the context of every HTTP request sent by a client from NewClient.
+func OperationFromContext(ctx context.Context) (*graphql.Request, bool) {
+	op, ok := ctx.Value(operationContextKey{}).(*graphql.Request)
+	return op, ok
+}
+
+// operationClient makes the GraphQL request available to HttpClient.Do
+// through OperationFromContext.
+type operationClient struct {
+	graphql.Client
+}
+
+func (oc *operationClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return oc.Client.MakeRequest(context.WithValue(ctx, operationContextKey{}, req), req, resp)
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+type options struct {
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	retryPolicy RetryPolicy
+	rateLimiter *RateLimiter
+	middleware  []Middleware
+	metrics     Metrics
+
+	logger             *slog.Logger
+	logLevels          LogLevels
+	logVariables       bool
+	sensitiveVariables []string
+}
+
+// Option configures a client created by NewClient.
+type Option func(*options)
+
+// WithHTTPClient sets the http.Client used for requests to the Fragment API.
+// It overrides the client provided to auth.WithHTTPClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for requests to the Fragment
+// API. It overrides the transport of any other configured http.Client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// getHTTPClient returns the configured http.Client, falling back to base
+// and then to a default client.
+func (o *options) getHTTPClient(base *http.Client) *http.Client {
+	if o.httpClient != nil {
+		base = o.httpClient
+	}
+	client := &http.Client{}
+	if base != nil {
+		*client = *base
+	}
+	if o.transport != nil {
+		client.Transport = o.transport
+	}
+	return client
+}
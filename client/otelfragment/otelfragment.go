@@ -0,0 +1,199 @@
+// Package otelfragment traces requests to the Fragment API with OpenTelemetry.
+//
+// Register it on a client with WithTracing:
+//
+//	ctx := client.WithOptions(authenticatedContext, otelfragment.WithTracing())
+//
+// Every GraphQL operation is recorded as a client span named after the
+// operation, such as AddLedgerEntry, and the trace context is propagated on
+// the outgoing request.
+package otelfragment
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fragment-dev/fragment-go/client"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/fragment-dev/fragment-go/client/otelfragment"
+
+// The attributes set on spans.
+const (
+	OperationNameKey = attribute.Key("graphql.operation.name")
+	OperationTypeKey = attribute.Key("graphql.operation.type")
+	StatusCodeKey    = attribute.Key("http.response.status_code")
+	IkKey            = attribute.Key("fragment.ik")
+	LedgerIkKey      = attribute.Key("fragment.ledger.ik")
+	EntryTypeKey     = attribute.Key("fragment.entry.type")
+	IsIkReplayKey    = attribute.Key("fragment.is_ik_replay")
+	ResultTypeKey    = attribute.Key("fragment.result.typename")
+	ErrorCodeKey     = attribute.Key("fragment.error.code")
+	RetryAttemptKey  = attribute.Key("fragment.retry.attempt")
+	RetryWaitKey     = attribute.Key("fragment.retry.wait_ms")
+)
+
+// The events added to spans.
+const (
+	TokenRefreshStartEvent = "token_refresh.start"
+	TokenRefreshDoneEvent  = "token_refresh.done"
+	RetryEvent             = "retry"
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the tracing middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the provider spans are created with. Defaults to
+// the global provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithPropagators sets the propagators used to inject the trace context into
+// outgoing requests. Defaults to the global propagators.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+// WithTracing returns a client option that traces every request.
+func WithTracing(opts ...Option) client.Option {
+	return client.WithMiddleware(Middleware(opts...))
+}
+
+// Middleware returns client middleware that records a span for every
+// GraphQL operation.
+func Middleware(opts ...Option) client.Middleware {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.tracerProvider == nil {
+		c.tracerProvider = otel.GetTracerProvider()
+	}
+	if c.propagators == nil {
+		c.propagators = otel.GetTextMapPropagator()
+	}
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+
+	return func(next client.Doer) client.Doer {
+		return client.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			name := "graphql"
+			var attrs []attribute.KeyValue
+			if op, ok := client.OperationFromContext(req.Context()); ok {
+				if op.OpName != "" {
+					name = op.OpName
+				}
+				attrs = operationAttributes(op.OpName, op.Query, op.Variables)
+			}
+
+			ctx, span := tracer.Start(req.Context(), name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...))
+			defer span.End()
+
+			ctx = client.WithClientTrace(ctx, &client.ClientTrace{
+				TokenRefreshStart: func() {
+					span.AddEvent(TokenRefreshStartEvent)
+				},
+				TokenRefreshDone: func(err error) {
+					if err != nil {
+						span.AddEvent(TokenRefreshDoneEvent, trace.WithAttributes(attribute.String("error", err.Error())))
+						return
+					}
+					span.AddEvent(TokenRefreshDoneEvent)
+				},
+				Retry: func(attempt int, wait time.Duration) {
+					span.AddEvent(RetryEvent, trace.WithAttributes(
+						RetryAttemptKey.Int(attempt),
+						RetryWaitKey.Int64(wait.Milliseconds())))
+				},
+			})
+			req = req.WithContext(ctx)
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			resp, err := next.Do(req)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+			recordResponse(span, resp)
+			return resp, nil
+		})
+	}
+}
+
+// operationAttributes returns the attributes describing an operation.
+func operationAttributes(opName string, query string, variables interface{}) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{OperationNameKey.String(opName)}
+	if opType, _, ok := strings.Cut(strings.TrimSpace(query), " "); ok {
+		attrs = append(attrs, OperationTypeKey.String(opType))
+	}
+
+	// The generated queries pass their variables as structs, so read them
+	// through their JSON encoding.
+	encoded, err := json.Marshal(variables)
+	if err != nil {
+		return attrs
+	}
+	var vars map[string]json.RawMessage
+	if json.Unmarshal(encoded, &vars) != nil {
+		return attrs
+	}
+	for _, variable := range []struct {
+		name string
+		key  attribute.Key
+	}{
+		{"ik", IkKey},
+		{"ledgerIk", LedgerIkKey},
+		{"entryType", EntryTypeKey},
+	} {
+		var value string
+		if json.Unmarshal(vars[variable.name], &value) == nil && value != "" {
+			attrs = append(attrs, variable.key.String(value))
+		}
+	}
+	return attrs
+}
+
+func recordResponse(span trace.Span, resp *http.Response) {
+	span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode != http.StatusOK {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		return
+	}
+	if !span.IsRecording() {
+		// Don't read the body for attributes that are dropped.
+		return
+	}
+	result, err := client.ReadOperationResult(resp)
+	if err != nil {
+		return
+	}
+	if result.Typename != "" {
+		span.SetAttributes(ResultTypeKey.String(result.Typename))
+	}
+	if result.IsIkReplay {
+		span.SetAttributes(IsIkReplayKey.Bool(true))
+	}
+	if result.Code != "" {
+		span.SetAttributes(ErrorCodeKey.String(result.Code))
+		span.SetStatus(codes.Error, result.Code)
+	}
+}
@@ -0,0 +1,111 @@
+package otelfragment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fragment-dev/fragment-go/auth"
+	"github.com/fragment-dev/fragment-go/client"
+	"github.com/fragment-dev/fragment-go/queries"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func getTracedContext(t *testing.T, handler http.HandlerFunc) (auth.AuthenticatedContext, *tracetest.InMemoryExporter) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, err := auth.NewAuthenticatedContext(
+		context.TODO(), server.URL, auth.StaticTokenSource(&auth.Token{AccessToken: "access_token"}))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+	return client.WithOptions(ctx, WithTracing(
+		WithTracerProvider(provider),
+		WithPropagators(propagation.TraceContext{}),
+	)), exporter
+}
+
+func getAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	var requests int32
+	ctx, exporter := getTracedContext(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("traceparent") == "" {
+			t.Errorf("Expected traceparent header to be set")
+		}
+		// Reject the first request, to trigger a token refresh.
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"addLedgerEntry":{"__typename":"AddLedgerEntryResult","isIkReplay":true,"entry":{},"lines":[]}}}`))
+	})
+
+	_, err := queries.AddLedgerEntry(ctx, "entry-ik", "ledger-ik", "deposit", nil, []byte(`{}`), nil, nil)
+	if err != nil {
+		t.Fatalf("Got error from AddLedgerEntry: %s", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "AddLedgerEntry" {
+		t.Errorf("Expected span name AddLedgerEntry, got %s", span.Name)
+	}
+	attrs := getAttributes(span)
+	for key, expected := range map[attribute.Key]attribute.Value{
+		OperationTypeKey: attribute.StringValue("mutation"),
+		IkKey:            attribute.StringValue("entry-ik"),
+		LedgerIkKey:      attribute.StringValue("ledger-ik"),
+		EntryTypeKey:     attribute.StringValue("deposit"),
+		IsIkReplayKey:    attribute.BoolValue(true),
+		ResultTypeKey:    attribute.StringValue("AddLedgerEntryResult"),
+	} {
+		if attrs[key] != expected {
+			t.Errorf("Expected %s to be %v, got %v", key, expected.Emit(), attrs[key].Emit())
+		}
+	}
+	var events []string
+	for _, event := range span.Events {
+		events = append(events, event.Name)
+	}
+	if len(events) != 2 || events[0] != TokenRefreshStartEvent || events[1] != TokenRefreshDoneEvent {
+		t.Errorf("Expected token refresh events, got %v", events)
+	}
+}
+
+func TestTracingErrorCode(t *testing.T) {
+	ctx, exporter := getTracedContext(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"createLedger":{"__typename":"BadRequestError","code":"bad_request","message":"Invalid schema"}}}`))
+	})
+
+	queries.CreateLedger(ctx, "ledger-ik", queries.CreateLedgerInput{Name: "Ledger"}, "schema-key")
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if code := getAttributes(spans[0])[ErrorCodeKey].AsString(); code != "bad_request" {
+		t.Errorf("Expected error code bad_request, got %s", code)
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("Expected span status Error, got %s", spans[0].Status.Code)
+	}
+}
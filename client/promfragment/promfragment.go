@@ -0,0 +1,113 @@
+// Package promfragment records metrics of requests to the Fragment API with
+// Prometheus.
+//
+// Register it on a client with client.WithMetrics:
+//
+//	metrics, err := promfragment.NewMetrics(prometheus.DefaultRegisterer)
+//	ctx := client.WithOptions(authenticatedContext, client.WithMetrics(metrics))
+package promfragment
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fragment-dev/fragment-go/client"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "fragment"
+
+// Metrics implements client.Metrics with Prometheus collectors, all labelled
+// by operation:
+//
+//   - fragment_request_duration_seconds, a histogram of operation latency.
+//   - fragment_request_errors_total, a counter of failed operations, labelled
+//     by code. The code is the Fragment error code, the HTTP status for
+//     non-OK responses, or "request_error" when no response was received.
+//   - fragment_request_retries_total, a counter of retries.
+//   - fragment_ik_replays_total, a counter of responses with isIkReplay set.
+//   - fragment_token_refreshes_total, a counter of token refreshes, labelled
+//     by result, "success" or "failure".
+type Metrics struct {
+	duration       *prometheus.HistogramVec
+	errors         *prometheus.CounterVec
+	retries        *prometheus.CounterVec
+	ikReplays      *prometheus.CounterVec
+	tokenRefreshes *prometheus.CounterVec
+}
+
+// NewMetrics returns Metrics whose collectors are registered with registerer.
+// If registerer is nil, prometheus.DefaultRegisterer is used.
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	m := &Metrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "The latency of operations sent to the Fragment API, including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_errors_total",
+			Help:      "The number of operations sent to the Fragment API that failed, by error code.",
+		}, []string{"operation", "code"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_retries_total",
+			Help:      "The number of times operations sent to the Fragment API were retried.",
+		}, []string{"operation"}),
+		ikReplays: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ik_replays_total",
+			Help:      "The number of mutations that replayed an earlier request with the same ik.",
+		}, []string{"operation"}),
+		tokenRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_refreshes_total",
+			Help:      "The number of access token refreshes made while sending operations, by result.",
+		}, []string{"operation", "result"}),
+	}
+	for _, collector := range []prometheus.Collector{m.duration, m.errors, m.retries, m.ikReplays, m.tokenRefreshes} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) ObserveRequest(r client.RequestMetrics) {
+	m.duration.WithLabelValues(r.Operation).Observe(r.Duration.Seconds())
+	if r.Retries > 0 {
+		m.retries.WithLabelValues(r.Operation).Add(float64(r.Retries))
+	}
+	if r.IsIkReplay {
+		m.ikReplays.WithLabelValues(r.Operation).Inc()
+	}
+	if code := errorCode(r); code != "" {
+		m.errors.WithLabelValues(r.Operation, code).Inc()
+	}
+}
+
+func (m *Metrics) ObserveTokenRefresh(operation string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.tokenRefreshes.WithLabelValues(operation, result).Inc()
+}
+
+// errorCode returns the code an operation failed with, or "" if it succeeded.
+func errorCode(r client.RequestMetrics) string {
+	switch {
+	case r.Err != nil:
+		return "request_error"
+	case r.Code != "":
+		return r.Code
+	case r.StatusCode != 0 && r.StatusCode != http.StatusOK:
+		return strconv.Itoa(r.StatusCode)
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package promfragment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fragment-dev/fragment-go/auth"
+	"github.com/fragment-dev/fragment-go/client"
+	"github.com/fragment-dev/fragment-go/queries"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			// Trigger a token refresh.
+			w.WriteHeader(http.StatusUnauthorized)
+		case 2:
+			// Trigger a retry.
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 3:
+			w.Write([]byte(`{"data":{"addLedgerEntry":{"__typename":"AddLedgerEntryResult","isIkReplay":true,"entry":{},"lines":[]}}}`))
+		default:
+			w.Write([]byte(`{"data":{"addLedgerEntry":{"__typename":"BadRequestError","code":"bad_request","message":"Invalid entry"}}}`))
+		}
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	if err != nil {
+		t.Fatalf("Got error from NewMetrics: %s", err)
+	}
+	ctx, err := auth.NewAuthenticatedContext(
+		context.TODO(), server.URL, auth.StaticTokenSource(&auth.Token{AccessToken: "access_token"}))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+	ctx = client.WithOptions(ctx,
+		client.WithMetrics(metrics),
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}))
+
+	if _, err := queries.AddLedgerEntry(ctx, "entry-ik", "ledger-ik", "deposit", nil, []byte(`{}`), nil, nil); err != nil {
+		t.Fatalf("Got error from AddLedgerEntry: %s", err)
+	}
+	queries.AddLedgerEntry(ctx, "entry-ik-2", "ledger-ik", "deposit", nil, []byte(`{}`), nil, nil)
+
+	if count := testutil.CollectAndCount(metrics.duration); count != 1 {
+		t.Errorf("Expected 1 latency series, got %d", count)
+	}
+	for name, test := range map[string]struct {
+		counter  prometheus.Collector
+		expected float64
+	}{
+		"retries":         {metrics.retries.WithLabelValues("AddLedgerEntry"), 1},
+		"ik replays":      {metrics.ikReplays.WithLabelValues("AddLedgerEntry"), 1},
+		"token refreshes": {metrics.tokenRefreshes.WithLabelValues("AddLedgerEntry", "success"), 1},
+		"errors":          {metrics.errors.WithLabelValues("AddLedgerEntry", "bad_request"), 1},
+	} {
+		if value := testutil.ToFloat64(test.counter); value != test.expected {
+			t.Errorf("Expected %s to be %v, got %v", name, test.expected, value)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// The factor by which a rate is cut when a 429 response is observed.
+	rateDecreaseFactor = 0.5
+	// The lowest fraction of its configured rate a rate can be cut to.
+	rateFloorFraction = 0.1
+	// The fraction of its configured rate a rate recovers by on every
+	// successful response.
+	rateRecoveryFraction = 0.01
+)
+
+// RateLimit configures a token bucket.
+type RateLimit struct {
+	// The sustained number of requests per second. Values of 0 or less
+	// disable the limit.
+	Rate float64
+	// The number of requests that can be made at once. Values below 1 are
+	// treated as 1.
+	Burst int
+}
+
+type bucket struct {
+	limit RateLimit
+	// The current rate, which adapts to 429 responses.
+	rate         float64
+	tokens       float64
+	last         time.Time
+	lastDecrease time.Time
+}
+
+func newBucket(limit RateLimit, now time.Time) *bucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &bucket{limit: limit, rate: limit.Rate, tokens: float64(limit.Burst), last: now}
+}
+
+// reserve takes a token from the bucket and returns how long to wait
+// before using it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > float64(b.limit.Burst) {
+		b.tokens = float64(b.limit.Burst)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+func (b *bucket) observe(status int, now time.Time) {
+	if status == http.StatusTooManyRequests {
+		// Cut the rate at most once a second, so a burst of 429s counts once.
+		if now.Sub(b.lastDecrease) < time.Second {
+			return
+		}
+		b.lastDecrease = now
+		b.rate *= rateDecreaseFactor
+		if floor := b.limit.Rate * rateFloorFraction; b.rate < floor {
+			b.rate = floor
+		}
+		return
+	}
+	b.rate += b.limit.Rate * rateRecoveryFraction
+	if b.rate > b.limit.Rate {
+		b.rate = b.limit.Rate
+	}
+}
+
+// RateLimiter is a token-bucket rate limiter for requests to the Fragment API,
+// keyed by GraphQL operation name. It is safe to share across goroutines and
+// clients. When a 429 response is observed, the rate of the operation's bucket
+// is cut, then recovers gradually as requests succeed.
+type RateLimiter struct {
+	clock        Clock
+	limit        RateLimit
+	perOperation map[string]RateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter returns a RateLimiter that applies limit to all operations
+// other than those in perOperation, which each get their own bucket.
+func NewRateLimiter(limit RateLimit, perOperation map[string]RateLimit) *RateLimiter {
+	return newRateLimiter(limit, perOperation, nil)
+}
+
+func newRateLimiter(limit RateLimit, perOperation map[string]RateLimit, clock Clock) *RateLimiter {
+	if clock == nil {
+		clock = getClock()
+	}
+	return &RateLimiter{
+		clock:        clock,
+		limit:        limit,
+		perOperation: perOperation,
+		buckets:      map[string]*bucket{},
+	}
+}
+
+// WithRateLimiter sets the limiter that requests wait on before being sent.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(o *options) {
+		o.rateLimiter = limiter
+	}
+}
+
+// getBucket returns the bucket for opName, or nil if it isn't limited.
+// The caller must hold rl.mu.
+func (rl *RateLimiter) getBucket(opName string) *bucket {
+	key := ""
+	limit := rl.limit
+	if opLimit, ok := rl.perOperation[opName]; ok {
+		key, limit = opName, opLimit
+	}
+	if limit.Rate <= 0 {
+		return nil
+	}
+	b, ok := rl.buckets[key]
+	if !ok {
+		b = newBucket(limit, rl.clock.Now())
+		rl.buckets[key] = b
+	}
+	return b
+}
+
+// Wait blocks until a request for opName may be sent, or ctx is done.
+func (rl *RateLimiter) Wait(ctx context.Context, opName string) error {
+	rl.mu.Lock()
+	b := rl.getBucket(opName)
+	if b == nil {
+		rl.mu.Unlock()
+		return nil
+	}
+	wait := b.reserve(rl.clock.Now())
+	rl.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-after(rl.clock, wait):
+		return nil
+	case <-ctx.Done():
+		// Give back the unused token.
+		rl.mu.Lock()
+		b.tokens++
+		rl.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Observe adapts the rate for opName to the status of a response.
+func (rl *RateLimiter) Observe(opName string, status int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if b := rl.getBucket(opName); b != nil {
+		b.observe(status, rl.clock.Now())
+	}
+}
+
+// Rate returns the current rate for opName in requests per second, or 0 if
+// it isn't limited.
+func (rl *RateLimiter) Rate(opName string) float64 {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if b := rl.getBucket(opName); b != nil {
+		return b.rate
+	}
+	return 0
+}
+
+// rateLimit holds requests back according to the client's RateLimiter, and
+// adapts its rate to their responses.
+func (c *HttpClient) rateLimit(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if c.rateLimiter == nil {
+			return next.Do(req)
+		}
+		var opName string
+		if op, ok := OperationFromContext(req.Context()); ok {
+			opName = op.OpName
+		}
+		if err := c.rateLimiter.Wait(req.Context(), opName); err != nil {
+			return nil, err
+		}
+		resp, err := next.Do(req)
+		if resp != nil {
+			c.rateLimiter.Observe(opName, resp.StatusCode)
+		}
+		return resp, err
+	})
+}
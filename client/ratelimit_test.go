@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	clock := newMockManualClock()
+	limiter := newRateLimiter(
+		RateLimit{Rate: 10, Burst: 2},
+		map[string]RateLimit{"AddLedgerEntry": {Rate: 1, Burst: 1}},
+		clock)
+
+	// The burst is available immediately.
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.TODO(), "GetLedger"); err != nil {
+			t.Errorf("Got error from Wait: %s", err)
+		}
+	}
+	select {
+	case wait := <-clock.waits:
+		t.Errorf("Expected the burst not to wait, waited %s", wait)
+	default:
+	}
+
+	done := make(chan error)
+	go func() { done <- limiter.Wait(context.TODO(), "GetLedger") }()
+	expectWait(t, clock, 100*time.Millisecond)
+	clock.fire <- clock.now
+	if err := <-done; err != nil {
+		t.Errorf("Got error from Wait: %s", err)
+	}
+
+	// AddLedgerEntry has its own bucket.
+	if err := limiter.Wait(context.TODO(), "AddLedgerEntry"); err != nil {
+		t.Errorf("Got error from Wait: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() { done <- limiter.Wait(ctx, "AddLedgerEntry") }()
+	expectWait(t, clock, time.Second)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRateLimiterAdapts(t *testing.T) {
+	clock := newMockManualClock()
+	limiter := newRateLimiter(RateLimit{Rate: 100, Burst: 1}, nil, clock)
+
+	limiter.Observe("AddLedgerEntry", http.StatusTooManyRequests)
+	// Further 429s within the same second don't cut the rate again.
+	limiter.Observe("AddLedgerEntry", http.StatusTooManyRequests)
+	if rate := limiter.Rate("AddLedgerEntry"); rate != 50 {
+		t.Errorf("Expected rate 50 after a 429, got %f", rate)
+	}
+
+	for i := 0; i < 10; i++ {
+		clock.now = clock.now.Add(time.Second)
+		limiter.Observe("AddLedgerEntry", http.StatusTooManyRequests)
+	}
+	if rate := limiter.Rate("AddLedgerEntry"); rate != 10 {
+		t.Errorf("Expected rate to be floored at 10, got %f", rate)
+	}
+
+	for i := 0; i < 100; i++ {
+		limiter.Observe("AddLedgerEntry", http.StatusOK)
+	}
+	if rate := limiter.Rate("AddLedgerEntry"); rate != 100 {
+		t.Errorf("Expected rate to recover to 100, got %f", rate)
+	}
+}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+const (
+	defaultRefreshFraction = 0.2
+	defaultRefreshJitter   = 0.1
+	defaultMinBackoff      = time.Second
+	defaultMaxBackoff      = time.Minute
+)
+
+// RefreshPolicy configures when a Refresher renews the access token.
+// Zero values are replaced with defaults.
+type RefreshPolicy struct {
+	// The fraction of a token's lifetime left before expiry at which
+	// it is renewed. Defaults to 0.2.
+	RefreshFraction float64
+	// The maximum fraction of the wait by which each refresh is brought
+	// forward at random, so that many processes don't refresh in lockstep.
+	// Defaults to 0.1.
+	Jitter float64
+	// The delay before retrying the first failed refresh. It doubles on
+	// every consecutive failure. Defaults to 1 second.
+	MinBackoff time.Duration
+	// The maximum delay between failed refreshes. Defaults to 1 minute.
+	MaxBackoff time.Duration
+}
+
+func (p RefreshPolicy) withDefaults() RefreshPolicy {
+	if p.RefreshFraction <= 0 || p.RefreshFraction >= 1 {
+		p.RefreshFraction = defaultRefreshFraction
+	}
+	if p.Jitter <= 0 || p.Jitter >= 1 {
+		p.Jitter = defaultRefreshJitter
+	}
+	if p.MinBackoff <= 0 {
+		p.MinBackoff = defaultMinBackoff
+	}
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = defaultMaxBackoff
+		if p.MaxBackoff < p.MinBackoff {
+			p.MaxBackoff = p.MinBackoff
+		}
+	}
+	return p
+}
+
+// RefresherStatus reports the health of a Refresher.
+type RefresherStatus struct {
+	// Whether the last refresh succeeded and the stored token is unexpired.
+	Healthy bool
+	// The time of the last successful refresh.
+	LastRefresh time.Time
+	// The error returned by the last refresh, if it failed.
+	LastError error
+	// The number of refreshes that have failed since the last success.
+	ConsecutiveFailures int
+	// The expiration time of the stored token.
+	ExpiresAt time.Time
+}
+
+// Refresher renews the access token of an AuthenticatedContext in the
+// background, so that requests don't wait on the OAuth round-trip.
+type Refresher struct {
+	ctx    auth.AuthenticatedContext
+	store  auth.TokenStore
+	source auth.TokenSource
+	policy RefreshPolicy
+	clock  Clock
+	rand   func() float64
+
+	mu                  sync.Mutex
+	lastRefresh         time.Time
+	lastError           error
+	consecutiveFailures int
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newRefresher(ctx auth.AuthenticatedContext, policy RefreshPolicy, clock Clock) *Refresher {
+	if clock == nil {
+		clock = getClock()
+	}
+	return &Refresher{
+		ctx:    ctx,
+		store:  tokenStoreOf(ctx),
+		source: tokenSourceOf(ctx),
+		policy: policy.withDefaults(),
+		clock:  clock,
+		rand:   rand.Float64,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// StartRefresher starts a goroutine that renews the access token of ctx before
+// it expires. It runs until ctx is done or Stop is called. If clock is nil,
+// the system clock is used.
+func StartRefresher(ctx auth.AuthenticatedContext, policy RefreshPolicy, clock Clock) *Refresher {
+	r := newRefresher(ctx, policy, clock)
+	go r.run()
+	return r
+}
+
+// Stop stops the refresher and waits for its goroutine to exit.
+func (r *Refresher) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+	<-r.done
+}
+
+// Status returns the current health of the refresher.
+func (r *Refresher) Status() RefresherStatus {
+	token, ok := r.store.Get()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := RefresherStatus{
+		LastRefresh:         r.lastRefresh,
+		LastError:           r.lastError,
+		ConsecutiveFailures: r.consecutiveFailures,
+	}
+	if ok {
+		status.ExpiresAt = token.ExpiresAt
+		status.Healthy = r.consecutiveFailures == 0 && !token.Expired(r.clock.Now())
+	}
+	return status
+}
+
+func (r *Refresher) run() {
+	defer close(r.done)
+
+	// The token last observed, and the time at which it was observed.
+	var observed *auth.Token
+	var observedAt time.Time
+	for {
+		token, ok := r.store.Get()
+		now := r.clock.Now()
+		if ok && token != observed {
+			observed, observedAt = token, now
+		}
+
+		var wait <-chan time.Time
+		switch {
+		case r.failures() > 0:
+			wait = after(r.clock, r.backoff())
+		case !ok:
+			wait = after(r.clock, 0)
+		case !token.ExpiresAt.IsZero():
+			wait = after(r.clock, r.untilRefresh(token, observedAt, now))
+		}
+
+		select {
+		case <-wait:
+		case <-r.ctx.Done():
+			return
+		case <-r.stop:
+			return
+		}
+
+		_, err := r.store.Refresh(token, r.source.Token)
+		r.record(err)
+	}
+}
+
+// untilRefresh returns how long to wait before renewing token, which had
+// the remaining lifetime ExpiresAt - observedAt when it was first observed.
+func (r *Refresher) untilRefresh(token *auth.Token, observedAt time.Time, now time.Time) time.Duration {
+	lifetime := token.ExpiresAt.Sub(observedAt)
+	wait := time.Duration(float64(lifetime) * (1 - r.policy.RefreshFraction))
+	wait -= time.Duration(float64(wait) * r.policy.Jitter * r.rand())
+	wait -= now.Sub(observedAt)
+	// Don't spin on tokens that are issued close to, or past, their expiry.
+	if wait < r.policy.MinBackoff {
+		return r.policy.MinBackoff
+	}
+	return wait
+}
+
+func (r *Refresher) backoff() time.Duration {
+	backoff := r.policy.MinBackoff
+	for i := 1; i < r.failures() && backoff < r.policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > r.policy.MaxBackoff {
+		backoff = r.policy.MaxBackoff
+	}
+	return backoff
+}
+
+func (r *Refresher) failures() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.consecutiveFailures
+}
+
+func (r *Refresher) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastError = err
+	if err != nil {
+		r.consecutiveFailures++
+		return
+	}
+	r.consecutiveFailures = 0
+	r.lastRefresh = r.clock.Now()
+}
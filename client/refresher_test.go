@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+// mockManualClock reports every wait on waits and only fires when the
+// test sends on fire.
+type mockManualClock struct {
+	now   time.Time
+	waits chan time.Duration
+	fire  chan time.Time
+}
+
+func newMockManualClock() *mockManualClock {
+	return &mockManualClock{
+		now:   time.Unix(1000, 0),
+		waits: make(chan time.Duration, 10),
+		fire:  make(chan time.Time),
+	}
+}
+
+func (mmc *mockManualClock) Now() time.Time {
+	return mmc.now
+}
+
+func (mmc *mockManualClock) After(d time.Duration) <-chan time.Time {
+	mmc.waits <- d
+	return mmc.fire
+}
+
+type tokenSourceFunc func() (*auth.Token, error)
+
+func (f tokenSourceFunc) Token() (*auth.Token, error) {
+	return f()
+}
+
+func startMockRefresher(t *testing.T, clock *mockManualClock, source auth.TokenSource) (*Refresher, auth.AuthenticatedContext) {
+	ctx, err := auth.NewAuthenticatedContext(context.TODO(), "https://api.example.com", auth.StaticTokenSource(&auth.Token{
+		AccessToken: "access_token",
+		ExpiresAt:   clock.now.Add(100 * time.Second),
+	}))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+	// Swap in the source under test once the initial token is stored.
+	mac := &mockAuthContext{Context: ctx, store: ctx.(auth.TokenStoreProvider).GetTokenStore(), source: source}
+	r := newRefresher(mac, RefreshPolicy{RefreshFraction: 0.2, MinBackoff: time.Second, MaxBackoff: 4 * time.Second}, clock)
+	r.rand = func() float64 { return 0 }
+	go r.run()
+	return r, mac
+}
+
+func expectWait(t *testing.T, clock *mockManualClock, expected time.Duration) {
+	select {
+	case wait := <-clock.waits:
+		if wait != expected {
+			t.Errorf("Expected refresher to wait %s, got %s", expected, wait)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timed out waiting for the refresher to wait %s", expected)
+	}
+}
+
+func TestRefresherRenewsBeforeExpiry(t *testing.T) {
+	clock := newMockManualClock()
+	var calls int32
+	source := tokenSourceFunc(func() (*auth.Token, error) {
+		atomic.AddInt32(&calls, 1)
+		return &auth.Token{AccessToken: "new_access_token", ExpiresAt: clock.now.Add(100 * time.Second)}, nil
+	})
+	r, mac := startMockRefresher(t, clock, source)
+	defer r.Stop()
+
+	expectWait(t, clock, 80*time.Second)
+	clock.fire <- clock.now
+	expectWait(t, clock, 80*time.Second)
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected 1 token fetch, got %d", n)
+	}
+	token, _ := mac.GetToken()
+	if token.AccessToken != "new_access_token" {
+		t.Errorf("Expected access token new_access_token, got %s", token.AccessToken)
+	}
+	if status := r.Status(); !status.Healthy || status.LastRefresh != clock.now {
+		t.Errorf("Expected a healthy refresher, got %+v", status)
+	}
+}
+
+func TestRefresherBacksOffOnFailure(t *testing.T) {
+	clock := newMockManualClock()
+	source := tokenSourceFunc(func() (*auth.Token, error) {
+		return nil, errors.New("unavailable")
+	})
+	r, _ := startMockRefresher(t, clock, source)
+	defer r.Stop()
+
+	expectWait(t, clock, 80*time.Second)
+	clock.fire <- clock.now
+	expectWait(t, clock, time.Second)
+	clock.fire <- clock.now
+	expectWait(t, clock, 2*time.Second)
+
+	status := r.Status()
+	if status.Healthy {
+		t.Errorf("Expected an unhealthy refresher")
+	}
+	if status.ConsecutiveFailures != 2 || status.LastError == nil {
+		t.Errorf("Expected 2 consecutive failures, got %+v", status)
+	}
+}
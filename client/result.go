@@ -0,0 +1,96 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// OperationResult summarises the response to a GraphQL operation.
+type OperationResult struct {
+	// The __typename of the operation's result, such as AddLedgerEntryResult
+	// or BadRequestError, if it was requested.
+	Typename string
+	// The code of the error returned by Fragment, if any.
+	Code string
+	// Whether a mutation was a replay of an earlier request with the same ik.
+	IsIkReplay bool
+}
+
+type operationResponse struct {
+	Data   map[string]operationResultFields `json:"data"`
+	Errors []struct {
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+type operationResultFields struct {
+	Typename   string `json:"__typename"`
+	Code       string `json:"code"`
+	IsIkReplay bool   `json:"isIkReplay"`
+}
+
+// UnmarshalJSON decodes the fields of an object result, and ignores results
+// that aren't objects. Other fields, such as the entries of a list, are
+// skipped without being copied.
+func (f *operationResultFields) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '{' {
+		return nil
+	}
+	type fields operationResultFields
+	return json.Unmarshal(data, (*fields)(f))
+}
+
+// resultBody is a response body that has been read to summarise it, along
+// with its summary, so that it's only read once however many middlewares
+// and tracers ask for it.
+type resultBody struct {
+	*bytes.Reader
+	result *OperationResult
+}
+
+func (*resultBody) Close() error {
+	return nil
+}
+
+// ReadOperationResult reads the body of resp and summarises it. The body is
+// replaced, so that it can be read again, and remembers the summary, so that
+// later calls with the same response don't read it again. As the whole body
+// is buffered, call it only when the summary is used. Operations generated
+// by this SDK select a single field; if there are several, one of them is
+// summarised.
+func ReadOperationResult(resp *http.Response) (*OperationResult, error) {
+	if body, ok := resp.Body.(*resultBody); ok {
+		return body.result, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed operationResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, err
+	}
+	result := &OperationResult{}
+	for _, fields := range parsed.Data {
+		if fields == (operationResultFields{}) {
+			// The result isn't an object, or has none of the fields.
+			continue
+		}
+		result.Typename = fields.Typename
+		result.Code = fields.Code
+		result.IsIkReplay = fields.IsIkReplay
+		break
+	}
+	if result.Code == "" && len(parsed.Errors) > 0 {
+		result.Code = parsed.Errors[0].Extensions.Code
+	}
+	resp.Body = &resultBody{Reader: bytes.NewReader(body), result: result}
+	return result, nil
+}
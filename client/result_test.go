@@ -0,0 +1,42 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReadOperationResult(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected OperationResult
+	}{
+		{`{"data":{"addLedgerEntry":{"__typename":"AddLedgerEntryResult","isIkReplay":true,"entry":{"ik":"ik"}}}}`,
+			OperationResult{Typename: "AddLedgerEntryResult", IsIkReplay: true}},
+		{`{"data":{"addLedgerEntry":{"__typename":"BadRequestError","code":"400"}}}`,
+			OperationResult{Typename: "BadRequestError", Code: "400"}},
+		{`{"data":{"ledger":null,"schema":{"__typename":"Schema"}}}`,
+			OperationResult{Typename: "Schema"}},
+		{`{"data":{"version":3}}`, OperationResult{}},
+		{`{"data":null,"errors":[{"extensions":{"code":"UNAUTHENTICATED"}}]}`,
+			OperationResult{Code: "UNAUTHENTICATED"}},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Body: io.NopCloser(strings.NewReader(tt.body))}
+		result, err := ReadOperationResult(resp)
+		if err != nil {
+			t.Errorf("Got error from ReadOperationResult for %s: %s", tt.body, err)
+			continue
+		}
+		if *result != tt.expected {
+			t.Errorf("Expected %+v for %s, got %+v", tt.expected, tt.body, *result)
+		}
+		if again, _ := ReadOperationResult(resp); again != result {
+			t.Errorf("Expected the result of %s to be read once", tt.body)
+		}
+		if body, _ := io.ReadAll(resp.Body); string(body) != tt.body {
+			t.Errorf("Expected the body to be readable again, got %s", body)
+		}
+	}
+}
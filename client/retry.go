@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+const (
+	defaultRetryMinBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff = 10 * time.Second
+)
+
+// DefaultRetryableStatuses are the HTTP statuses retried when a RetryPolicy
+// doesn't set RetryableStatuses.
+var DefaultRetryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy configures how a client retries failed requests. Zero values,
+// other than MaxAttempts, are replaced with defaults.
+type RetryPolicy struct {
+	// The maximum number of attempts, including the first. Values below 2
+	// disable retries.
+	MaxAttempts int
+	// The delay before the first retry. It doubles on every attempt.
+	// Defaults to 100 milliseconds.
+	MinBackoff time.Duration
+	// The maximum delay between attempts. Defaults to 10 seconds.
+	MaxBackoff time.Duration
+	// The HTTP statuses that are retried. Defaults to DefaultRetryableStatuses.
+	RetryableStatuses []int
+	// Reports whether a request error is transient. Defaults to IsRetryableError.
+	RetryableError func(error) bool
+	// Reports whether an operation is safe to retry. Defaults to IsIdempotent.
+	Idempotent func(*graphql.Request) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that makes up to 3 attempts.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests. By default,
+// requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MinBackoff <= 0 {
+		p.MinBackoff = defaultRetryMinBackoff
+	}
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = defaultRetryMaxBackoff
+		if p.MaxBackoff < p.MinBackoff {
+			p.MaxBackoff = p.MinBackoff
+		}
+	}
+	if p.RetryableStatuses == nil {
+		p.RetryableStatuses = DefaultRetryableStatuses
+	}
+	if p.RetryableError == nil {
+		p.RetryableError = IsRetryableError
+	}
+	if p.Idempotent == nil {
+		p.Idempotent = IsIdempotent
+	}
+	return p
+}
+
+// shouldRetry reports whether the outcome of an attempt at req is retryable.
+func (p RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	op, _ := OperationFromContext(req.Context())
+	if !p.Idempotent(op) {
+		return false
+	}
+	if err != nil {
+		return p.RetryableError(err)
+	}
+	for _, status := range p.RetryableStatuses {
+		if resp.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the attempt following attempt. It honours
+// the Retry-After header of resp, if present.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response, now time.Time, rand func() float64) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			return wait
+		}
+	}
+	backoff := p.MinBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	// Wait at least half the backoff, and a random amount of the rest.
+	return backoff/2 + time.Duration(float64(backoff/2)*rand())
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// IsRetryableError reports whether err is a transient network error, such as
+// a connection reset or a timeout.
+func IsRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// ikArgument matches an ik argument passed directly to a mutation field,
+// such as addLedgerEntry(ik: $ik, ...), as opposed to one nested in a match
+// input such as ledger: {ik: $ledgerIk}.
+var ikArgument = regexp.MustCompile(`[(,]\s*ik\s*:\s*\$`)
+
+// IsIdempotent reports whether op is safe to retry: queries always are, and
+// mutations are when they're keyed by an ik argument, like AddLedgerEntry,
+// CreateLedger and CreateCustomLink.
+func IsIdempotent(op *graphql.Request) bool {
+	if op == nil {
+		return false
+	}
+	query := strings.TrimSpace(op.Query)
+	if strings.HasPrefix(query, "query") {
+		return true
+	}
+	return strings.HasPrefix(query, "mutation") && ikArgument.MatchString(query)
+}
+
+// retry retries transient failures of idempotent operations according to
+// the client's RetryPolicy.
+func (c *HttpClient) retry(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		for attempt := 1; ; attempt++ {
+			resp, err := next.Do(req)
+			if attempt >= c.retryPolicy.MaxAttempts || !c.retryPolicy.shouldRetry(req, resp, err) {
+				return resp, err
+			}
+
+			wait := c.retryPolicy.backoff(attempt, resp, c.clock.Now(), c.rand)
+			// Don't retry if the request would time out before the next attempt.
+			if deadline, ok := req.Context().Deadline(); ok && c.clock.Now().Add(wait).After(deadline) {
+				return resp, err
+			}
+			if resp != nil {
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+			ContextClientTrace(req.Context()).retry(attempt, wait)
+			select {
+			case <-after(c.clock, wait):
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+
+			if req, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+	})
+}
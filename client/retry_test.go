@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/fragment-dev/fragment-go/auth"
+)
+
+const (
+	getWorkspaceQuery = `query GetWorkspace { workspace { id } }`
+	addLedgerEntry    = `mutation AddLedgerEntry ($ik: SafeString!) { addLedgerEntry(ik: $ik, entry: {ledger:{ik:$ledgerIk}}) { __typename } }`
+	updateLedger      = `mutation UpdateLedger ($ledgerIk: SafeString!) { updateLedger(ledger: {ik:$ledgerIk}) { __typename } }`
+)
+
+// getFlakyMockServer fails the first failures requests with status, then succeeds.
+func getFlakyMockServer(failures int32, status int, retryAfter string, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+}
+
+func getRetryingClient(t *testing.T, serverUrl string, policy RetryPolicy) (graphql.Client, auth.AuthenticatedContext) {
+	ctx, err := auth.NewAuthenticatedContext(
+		context.TODO(), serverUrl, auth.StaticTokenSource(&auth.Token{AccessToken: "access_token"}))
+	if err != nil {
+		t.Fatalf("Got error from NewAuthenticatedContext: %s", err)
+	}
+	client, _ := NewClient(ctx, WithRetryPolicy(policy))
+	return client, ctx
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	var requests int32
+	server := getFlakyMockServer(2, http.StatusServiceUnavailable, "", &requests)
+	defer server.Close()
+
+	client, ctx := getRetryingClient(t, server.URL, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	for _, query := range []string{getWorkspaceQuery, addLedgerEntry} {
+		atomic.StoreInt32(&requests, 0)
+		err := client.MakeRequest(ctx, &graphql.Request{Query: query}, &graphql.Response{})
+		if err != nil {
+			t.Errorf("Got error from MakeRequest: %s", err)
+		}
+		if requests != 3 {
+			t.Errorf("Expected 3 requests, got %d", requests)
+		}
+	}
+}
+
+func TestRetrySkipsNonIdempotentMutation(t *testing.T) {
+	var requests int32
+	server := getFlakyMockServer(2, http.StatusServiceUnavailable, "", &requests)
+	defer server.Close()
+
+	client, ctx := getRetryingClient(t, server.URL, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	err := client.MakeRequest(ctx, &graphql.Request{Query: updateLedger}, &graphql.Response{})
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	var requests int32
+	server := getFlakyMockServer(2, http.StatusTooManyRequests, "30", &requests)
+	defer server.Close()
+
+	client, ctx := getRetryingClient(t, server.URL, RetryPolicy{MaxAttempts: 3})
+	deadlineCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := client.MakeRequest(deadlineCtx, &graphql.Request{Query: getWorkspaceQuery}, &graphql.Response{})
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected MakeRequest to give up without waiting, took %s", elapsed)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 4 * time.Second}.withDefaults()
+	now := time.Unix(1000, 0)
+	noJitter := func() float64 { return 1 }
+
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 5: 4 * time.Second} {
+		if wait := policy.backoff(attempt, nil, now, noJitter); wait != expected {
+			t.Errorf("Expected attempt %d to wait %s, got %s", attempt, expected, wait)
+		}
+	}
+
+	for retryAfter, expected := range map[string]time.Duration{
+		"7": 7 * time.Second,
+		now.Add(30 * time.Second).UTC().Format(http.TimeFormat): 30 * time.Second,
+	} {
+		resp := &http.Response{Header: http.Header{"Retry-After": []string{retryAfter}}}
+		if wait := policy.backoff(1, resp, now, noJitter); wait != expected {
+			t.Errorf("Expected Retry-After %s to wait %s, got %s", retryAfter, expected, wait)
+		}
+	}
+}
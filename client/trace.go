@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+type clientTraceContextKey struct{}
+
+// ClientTrace is a set of hooks run at stages of a request to the Fragment
+// API. Any of them may be nil.
+type ClientTrace struct {
+	// TokenRefreshStart is called before the request waits on a new access
+	// token, because the stored one expired or was rejected.
+	TokenRefreshStart func()
+	// TokenRefreshDone is called when the new access token is available, or
+	// with the error that prevented it.
+	TokenRefreshDone func(err error)
+	// Retry is called before a failed attempt is retried, with the number of
+	// the failed attempt and the delay before the next.
+	Retry func(attempt int, wait time.Duration)
+}
+
+// WithClientTrace returns a copy of ctx whose requests call the hooks of
+// trace. Hooks already set on ctx are called after those of trace.
+func WithClientTrace(ctx context.Context, trace *ClientTrace) context.Context {
+	if old := ContextClientTrace(ctx); old != nil {
+		trace = trace.compose(old)
+	}
+	return context.WithValue(ctx, clientTraceContextKey{}, trace)
+}
+
+// ContextClientTrace returns the ClientTrace set on ctx, or nil.
+func ContextClientTrace(ctx context.Context) *ClientTrace {
+	trace, _ := ctx.Value(clientTraceContextKey{}).(*ClientTrace)
+	return trace
+}
+
+// compose returns a trace that calls the hooks of t, then those of old.
+func (t *ClientTrace) compose(old *ClientTrace) *ClientTrace {
+	return &ClientTrace{
+		TokenRefreshStart: func() {
+			if t.TokenRefreshStart != nil {
+				t.TokenRefreshStart()
+			}
+			if old.TokenRefreshStart != nil {
+				old.TokenRefreshStart()
+			}
+		},
+		TokenRefreshDone: func(err error) {
+			if t.TokenRefreshDone != nil {
+				t.TokenRefreshDone(err)
+			}
+			if old.TokenRefreshDone != nil {
+				old.TokenRefreshDone(err)
+			}
+		},
+		Retry: func(attempt int, wait time.Duration) {
+			if t.Retry != nil {
+				t.Retry(attempt, wait)
+			}
+			if old.Retry != nil {
+				old.Retry(attempt, wait)
+			}
+		},
+	}
+}
+
+func (t *ClientTrace) tokenRefreshStart() {
+	if t != nil && t.TokenRefreshStart != nil {
+		t.TokenRefreshStart()
+	}
+}
+
+func (t *ClientTrace) tokenRefreshDone(err error) {
+	if t != nil && t.TokenRefreshDone != nil {
+		t.TokenRefreshDone(err)
+	}
+}
+
+func (t *ClientTrace) retry(attempt int, wait time.Duration) {
+	if t != nil && t.Retry != nil {
+		t.Retry(attempt, wait)
+	}
+}
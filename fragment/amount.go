@@ -0,0 +1,155 @@
+package fragment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Amount is an integer number of minor units, such as cents, of arbitrary
+// precision. It is bound to the Int96 and Int64 scalars of the Fragment API.
+//
+// Amounts are immutable: arithmetic returns a new Amount. The zero value is 0.
+type Amount struct {
+	v *big.Int
+}
+
+// NewAmount returns an Amount of n minor units.
+func NewAmount(n int64) Amount {
+	return Amount{big.NewInt(n)}
+}
+
+// AmountFromBigInt returns an Amount of n minor units.
+func AmountFromBigInt(n *big.Int) Amount {
+	return Amount{new(big.Int).Set(n)}
+}
+
+// ParseAmount parses a base 10 integer number of minor units, such as "-1234".
+func ParseAmount(s string) (Amount, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("Invalid amount %q", s)
+	}
+	return Amount{v}, nil
+}
+
+// MustParseAmount is like ParseAmount, but panics if s isn't a valid amount.
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (a Amount) int() *big.Int {
+	if a.v == nil {
+		return new(big.Int)
+	}
+	return a.v
+}
+
+// BigInt returns the number of minor units of a.
+func (a Amount) BigInt() *big.Int {
+	return new(big.Int).Set(a.int())
+}
+
+// Int64 returns the number of minor units of a, and whether it fits in an int64.
+func (a Amount) Int64() (int64, bool) {
+	return a.int().Int64(), a.int().IsInt64()
+}
+
+// Add returns a + b.
+func (a Amount) Add(b Amount) Amount {
+	return Amount{new(big.Int).Add(a.int(), b.int())}
+}
+
+// Sub returns a - b.
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{new(big.Int).Sub(a.int(), b.int())}
+}
+
+// Mul returns a * n.
+func (a Amount) Mul(n int64) Amount {
+	return Amount{new(big.Int).Mul(a.int(), big.NewInt(n))}
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return Amount{new(big.Int).Neg(a.int())}
+}
+
+// Abs returns the absolute value of a.
+func (a Amount) Abs() Amount {
+	return Amount{new(big.Int).Abs(a.int())}
+}
+
+// Cmp compares a and b, returning -1 if a < b, 0 if a == b and +1 if a > b.
+func (a Amount) Cmp(b Amount) int {
+	return a.int().Cmp(b.int())
+}
+
+// Equal reports whether a == b.
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+// Sign returns -1 if a < 0, 0 if a == 0 and +1 if a > 0.
+func (a Amount) Sign() int {
+	return a.int().Sign()
+}
+
+// IsZero reports whether a == 0.
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// String returns a in minor units, such as "-1234".
+func (a Amount) String() string {
+	return a.int().String()
+}
+
+// Format returns a in major units, for a currency whose minor unit is
+// 10^-exponent of its major unit. For example, 1234 with exponent 2 is "12.34".
+func (a Amount) Format(exponent int) string {
+	if exponent <= 0 {
+		return a.String()
+	}
+	digits := new(big.Int).Abs(a.int()).String()
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	point := len(digits) - exponent
+	formatted := digits[:point] + "." + digits[point:]
+	if a.Sign() < 0 {
+		return "-" + formatted
+	}
+	return formatted
+}
+
+// MarshalJSON encodes a as a JSON string of minor units, as the Fragment API
+// expects.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes a JSON string or number of minor units.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(bytes.TrimSpace(data))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,72 @@
+package fragment
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAmountArithmetic(t *testing.T) {
+	// 2^95 - 1, the largest Int96, doesn't fit in an int64.
+	max := MustParseAmount("39614081257132168796771975167")
+	sum := max.Add(NewAmount(1)).Sub(NewAmount(1))
+	if !sum.Equal(max) {
+		t.Errorf("Expected %s, got %s", max, sum)
+	}
+	if _, ok := max.Int64(); ok {
+		t.Errorf("Expected %s not to fit in an int64", max)
+	}
+
+	var zero Amount
+	if !zero.IsZero() || zero.String() != "0" {
+		t.Errorf("Expected the zero Amount to be 0, got %s", zero)
+	}
+	if got := zero.Sub(NewAmount(150)).Mul(2); got.String() != "-300" {
+		t.Errorf("Expected -300, got %s", got)
+	}
+	if NewAmount(-5).Abs().Cmp(NewAmount(4)) != 1 || NewAmount(5).Neg().Sign() != -1 {
+		t.Errorf("Expected comparisons of 5 and -5 to hold")
+	}
+}
+
+func TestAmountFormat(t *testing.T) {
+	for _, test := range []struct {
+		amount   int64
+		exponent int
+		expected string
+	}{
+		{1234, 2, "12.34"},
+		{-1234, 2, "-12.34"},
+		{5, 2, "0.05"},
+		{-5, 3, "-0.005"},
+		{1234, 0, "1234"},
+	} {
+		if got := NewAmount(test.amount).Format(test.exponent); got != test.expected {
+			t.Errorf("Expected %d with exponent %d to be %s, got %s", test.amount, test.exponent, test.expected, got)
+		}
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var line struct {
+		Amount  Amount  `json:"amount"`
+		Balance *Amount `json:"balance"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"-39614081257132168796771975167","balance":100}`), &line); err != nil {
+		t.Fatalf("Got error from Unmarshal: %s", err)
+	}
+	if line.Amount.String() != "-39614081257132168796771975167" || line.Balance.String() != "100" {
+		t.Errorf("Expected amounts to be decoded, got %s and %s", line.Amount, line.Balance)
+	}
+
+	encoded, err := json.Marshal(line)
+	if err != nil {
+		t.Fatalf("Got error from Marshal: %s", err)
+	}
+	if string(encoded) != `{"amount":"-39614081257132168796771975167","balance":"100"}` {
+		t.Errorf("Expected amounts to be encoded as strings, got %s", encoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"12.34"}`), &line); err == nil {
+		t.Errorf("Expected an error decoding a fractional amount")
+	}
+}
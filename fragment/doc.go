@@ -0,0 +1,3 @@
+// Package fragment contains types shared by the generated queries and the
+// helpers built on them, that don't depend on the generated code.
+package fragment
@@ -0,0 +1,71 @@
+package fragment
+
+import "fmt"
+
+// APIError is an error result returned by the Fragment API, such as a
+// BadRequestError or an InternalError.
+type APIError struct {
+	// The error code, such as bad_request.
+	Code string
+	// A description of the error.
+	Message string
+	// The GraphQL type of the error, such as BadRequestError.
+	Typename string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Fragment returned %s (%s): %s", e.Typename, e.Code, e.Message)
+}
+
+// errorResult is implemented by the generated error variants of mutation
+// results.
+type errorResult interface {
+	GetTypename() *string
+	GetCode() string
+	GetMessage() string
+}
+
+// AsAPIError returns the APIError held by result, if it is an error variant
+// of a generated mutation result.
+func AsAPIError(result interface{}) (*APIError, bool) {
+	r, ok := result.(errorResult)
+	if !ok {
+		return nil, false
+	}
+	err := &APIError{Code: r.GetCode(), Message: r.GetMessage()}
+	if typename := r.GetTypename(); typename != nil {
+		err.Typename = *typename
+	}
+	return err, true
+}
+
+// Unwrap returns result as R, the success variant of a generated mutation
+// result, or the APIError it holds.
+func Unwrap[R any](result interface{}) (R, error) {
+	var zero R
+	if err, ok := AsAPIError(result); ok {
+		return zero, err
+	}
+	if r, ok := result.(R); ok {
+		return r, nil
+	}
+	return zero, fmt.Errorf("Unexpected result of type %T", result)
+}
+
+// resulter is implemented by the responses of generated mutations.
+type resulter[R any] interface {
+	Result() (R, error)
+}
+
+// Result returns the success variant of the result of a generated mutation,
+// or the error that prevented it. Errors returned by the Fragment API are
+// of type *APIError.
+//
+//	result, err := fragment.Result(queries.AddLedgerEntry(ctx, ...))
+func Result[R any, P resulter[R]](response P, err error) (R, error) {
+	if err != nil {
+		var zero R
+		return zero, err
+	}
+	return response.Result()
+}
@@ -0,0 +1,55 @@
+package fragment_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/fragment-dev/fragment-go/fragment"
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+func getAddLedgerEntryResponse(t *testing.T, body string) *queries.AddLedgerEntryResponse {
+	var response queries.AddLedgerEntryResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("Got error unmarshalling response: %s", err)
+	}
+	return &response
+}
+
+func TestResult(t *testing.T) {
+	response := getAddLedgerEntryResponse(t, `{"addLedgerEntry":{"__typename":"AddLedgerEntryResult","isIkReplay":true,"entry":{"ik":"entry-ik"}}}`)
+
+	result, err := fragment.Result(response, nil)
+	if err != nil {
+		t.Fatalf("Got error from Result: %s", err)
+	}
+	if !result.IsIkReplay || result.Entry.Ik != "entry-ik" {
+		t.Errorf("Expected result for entry-ik, got %+v", result)
+	}
+}
+
+func TestResultAPIError(t *testing.T) {
+	response := getAddLedgerEntryResponse(t, `{"addLedgerEntry":{"__typename":"BadRequestError","code":"bad_request","message":"Invalid entry"}}`)
+
+	result, err := fragment.Result(response, nil)
+	if result != nil {
+		t.Errorf("Expected no result, got %+v", result)
+	}
+	var apiErr *fragment.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *fragment.APIError, got %T", err)
+	}
+	expected := fragment.APIError{Code: "bad_request", Message: "Invalid entry", Typename: "BadRequestError"}
+	if *apiErr != expected {
+		t.Errorf("Expected %+v, got %+v", expected, *apiErr)
+	}
+}
+
+func TestResultRequestError(t *testing.T) {
+	requestErr := errors.New("connection refused")
+	_, err := fragment.Result((*queries.AddLedgerEntryResponse)(nil), requestErr)
+	if err != requestErr {
+		t.Errorf("Expected request error, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package ik builds idempotency keys for the mutations of the Fragment API,
+// such as AddLedgerEntry, CreateLedger and CreateCustomLink.
+//
+// A mutation retried with the same IK is applied once, so an IK derived from
+// the business event it records, with Namespace.Key, prevents double-posting
+// when a request is retried by another process or after a restart.
+package ik
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// MaxLength is the maximum length of a SafeString, and so of an IK.
+const MaxLength = 128
+
+// separator joins a Namespace to the hash or random part of an IK.
+const separator = ":"
+
+// hashLength is the length of the hex-encoded SHA-256 hash of an IK.
+const hashLength = 2 * sha256.Size
+
+// MaxNamespaceLength is the maximum length of a Namespace, which leaves room
+// in an IK for the separator and hash.
+const MaxNamespaceLength = MaxLength - len(separator) - hashLength
+
+// Validate returns an error if ik isn't a valid SafeString: between 1 and 128
+// characters, each a letter, digit, '-', '_', '.' or ':'.
+func Validate(ik string) error {
+	if ik == "" {
+		return fmt.Errorf("IK must not be empty")
+	}
+	if len(ik) > MaxLength {
+		return fmt.Errorf("IK %q is longer than %d characters", ik, MaxLength)
+	}
+	for _, c := range ik {
+		if !isSafe(c) {
+			return fmt.Errorf("IK %q contains %q, which isn't allowed in a SafeString", ik, c)
+		}
+	}
+	return nil
+}
+
+func isSafe(c rune) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	case c == '-', c == '_', c == '.', c == ':':
+		return true
+	}
+	return false
+}
+
+// Namespace scopes the IKs of one kind of business event, such as
+// "payout" or "user-signup", so that the same fields in different
+// namespaces produce different IKs.
+type Namespace struct {
+	name string
+}
+
+// NewNamespace returns the Namespace name. It returns an error if name isn't
+// a valid SafeString, or is longer than MaxNamespaceLength.
+func NewNamespace(name string) (Namespace, error) {
+	if err := Validate(name); err != nil {
+		return Namespace{}, fmt.Errorf("Invalid namespace: %w", err)
+	}
+	if len(name) > MaxNamespaceLength {
+		return Namespace{}, fmt.Errorf("Namespace %q is longer than %d characters", name, MaxNamespaceLength)
+	}
+	return Namespace{name}, nil
+}
+
+// MustNamespace is like NewNamespace, but panics if name isn't valid.
+func MustNamespace(name string) Namespace {
+	n, err := NewNamespace(name)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// String returns the name of n.
+func (n Namespace) String() string {
+	return n.name
+}
+
+// Key returns the IK of the event identified by fields in n, such as a user
+// and payout ID. The same namespace and fields always produce the same IK,
+// made of the namespace and a SHA-256 hash of the fields.
+func (n Namespace) Key(fields ...string) string {
+	h := sha256.New()
+	write := func(s string) {
+		// Prefix every value with its length, so that ("ab", "c") and
+		// ("a", "bc") don't collide.
+		var length [binary.MaxVarintLen64]byte
+		h.Write(length[:binary.PutUvarint(length[:], uint64(len(s)))])
+		h.Write([]byte(s))
+	}
+	write(n.name)
+	for _, field := range fields {
+		write(field)
+	}
+	return n.name + separator + hex.EncodeToString(h.Sum(nil))
+}
+
+// Random returns a random IK in n, for events that have no natural identity.
+// Store it with the event before making the request, so that retries reuse it.
+func (n Namespace) Random() string {
+	return n.name + separator + random()
+}
+
+// Random returns a random IK of 32 hex characters.
+func Random() string {
+	return random()
+}
+
+func random() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("Failed to read random bytes: %s", err))
+	}
+	return hex.EncodeToString(b[:])
+}
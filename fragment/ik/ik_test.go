@@ -0,0 +1,59 @@
+package ik
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	payouts := MustNamespace("payout")
+	key := payouts.Key("user-1", "42")
+	if key != payouts.Key("user-1", "42") {
+		t.Errorf("Expected the same fields to produce the same IK")
+	}
+	if !strings.HasPrefix(key, "payout:") || len(key) != len("payout:")+hashLength {
+		t.Errorf("Expected a namespaced hash, got %s", key)
+	}
+	if err := Validate(key); err != nil {
+		t.Errorf("Expected %s to be valid, got %s", key, err)
+	}
+
+	for _, other := range []string{
+		payouts.Key("user-14", "2"),
+		payouts.Key("user-1", "42", ""),
+		MustNamespace("refund").Key("user-1", "42"),
+	} {
+		if other == key {
+			t.Errorf("Expected different fields or namespaces to produce different IKs, got %s twice", key)
+		}
+	}
+
+	long := MustNamespace(strings.Repeat("n", MaxNamespaceLength))
+	if err := Validate(long.Key("user-1")); err != nil {
+		t.Errorf("Expected the IK of the longest namespace to be valid, got %s", err)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	first, second := Random(), Random()
+	if first == second || len(first) != 32 {
+		t.Errorf("Expected distinct random IKs of 32 characters, got %s and %s", first, second)
+	}
+	if key := MustNamespace("signup").Random(); !strings.HasPrefix(key, "signup:") || Validate(key) != nil {
+		t.Errorf("Expected a valid namespaced IK, got %s", key)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, ik := range []string{"", "has space", "slash/ed", "émoji", strings.Repeat("a", MaxLength+1)} {
+		if Validate(ik) == nil {
+			t.Errorf("Expected %q to be invalid", ik)
+		}
+	}
+	if _, err := NewNamespace(strings.Repeat("n", MaxNamespaceLength+1)); err == nil {
+		t.Errorf("Expected an error for a namespace too long to fit a hash")
+	}
+	if _, err := NewNamespace("bad namespace"); err == nil {
+		t.Errorf("Expected an error for a namespace with a space")
+	}
+}
@@ -0,0 +1,207 @@
+package fragment
+
+import "context"
+
+// PageInfo is the pagination info of a page of a connection.
+type PageInfo struct {
+	HasNextPage     bool
+	EndCursor       *string
+	HasPreviousPage bool
+	StartCursor     *string
+}
+
+// Page is a page of a connection, such as the Ledger Entries of a Ledger.
+type Page[T any] struct {
+	Nodes    []T
+	PageInfo PageInfo
+}
+
+// PageRequest holds the pagination arguments of a request for a page.
+type PageRequest struct {
+	After  *string
+	First  *int
+	Before *string
+}
+
+// FetchPage fetches the page of a connection selected by req. The requests it
+// makes should be cancelled when ctx is done.
+type FetchPage[T any] func(ctx context.Context, req PageRequest) (*Page[T], error)
+
+type pageOptions struct {
+	pageSize   int
+	cursor     *string
+	backward   bool
+	buffer     int
+	checkpoint func(cursor string) error
+}
+
+// PageOption configures the traversal of a connection.
+type PageOption func(*pageOptions)
+
+// WithPageSize sets the number of nodes fetched in each page. By default, the
+// API's page size is used.
+func WithPageSize(size int) PageOption {
+	return func(o *pageOptions) {
+		o.pageSize = size
+	}
+}
+
+// WithCursor starts the traversal after cursor, or before it when traversing
+// backward. Pass the cursor of an earlier traversal to resume it.
+func WithCursor(cursor string) PageOption {
+	return func(o *pageOptions) {
+		o.cursor = &cursor
+	}
+}
+
+// Backward traverses the connection towards its start, following the
+// startCursor of each page. Nodes are returned in reverse order.
+func Backward() PageOption {
+	return func(o *pageOptions) {
+		o.backward = true
+	}
+}
+
+// WithBuffer sets the number of fetched pages a Paginator holds ahead of the
+// page being processed. Defaults to 1. The Paginator also fetches one more
+// page while it waits for room, so even with a buffer of 0 the next page is
+// prefetched.
+func WithBuffer(pages int) PageOption {
+	return func(o *pageOptions) {
+		o.buffer = pages
+	}
+}
+
+// WithCheckpoint sets a function that a Paginator calls with the cursor that
+// resumes the traversal after each page, once the page has been processed.
+// Save the cursor, and pass it to WithCursor to resume an interrupted
+// traversal. If save returns an error, the traversal stops with it.
+func WithCheckpoint(save func(cursor string) error) PageOption {
+	return func(o *pageOptions) {
+		o.checkpoint = save
+	}
+}
+
+func newPageOptions(opts []PageOption) *pageOptions {
+	o := &pageOptions{buffer: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// request returns the request for the page after, or before, cursor.
+func (o *pageOptions) request(cursor *string) PageRequest {
+	req := PageRequest{}
+	if o.pageSize > 0 {
+		first := o.pageSize
+		req.First = &first
+	}
+	if o.backward {
+		req.Before = cursor
+	} else {
+		req.After = cursor
+	}
+	return req
+}
+
+// nextCursor returns the cursor of the page following info in the direction
+// of traversal, and whether there is such a page.
+func (o *pageOptions) nextCursor(info PageInfo) (*string, bool) {
+	if o.backward {
+		return info.StartCursor, info.HasPreviousPage && info.StartCursor != nil
+	}
+	return info.EndCursor, info.HasNextPage && info.EndCursor != nil
+}
+
+// orient returns page with its nodes in the order of traversal.
+func orient[T any](o *pageOptions, page *Page[T]) *Page[T] {
+	if !o.backward {
+		return page
+	}
+	return &Page[T]{Nodes: reversed(page.Nodes), PageInfo: page.PageInfo}
+}
+
+// Iterator iterates over the nodes of a connection, fetching pages lazily.
+//
+//	it := queries.AllLedgerEntries(ctx, ledgerIk, nil)
+//	for it.Next() {
+//		entry := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch FetchPage[T]
+	opts  *pageOptions
+
+	nodes  []T
+	value  T
+	cursor *string
+	done   bool
+	err    error
+}
+
+// NewIterator returns an Iterator over the nodes of the pages returned by
+// fetch. It stops when ctx is done, which is passed to fetch to cancel the
+// request in flight.
+func NewIterator[T any](ctx context.Context, fetch FetchPage[T], opts ...PageOption) *Iterator[T] {
+	o := newPageOptions(opts)
+	return &Iterator[T]{ctx: ctx, fetch: fetch, opts: o, cursor: o.cursor}
+}
+
+// Next advances the iterator to the next node, fetching the next page if
+// needed. It returns false when there are no more nodes, or a page couldn't
+// be fetched.
+func (it *Iterator[T]) Next() bool {
+	for len(it.nodes) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		page, err := it.fetch(it.ctx, it.opts.request(it.cursor))
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.nodes = orient(it.opts, page).Nodes
+		var ok bool
+		it.cursor, ok = it.opts.nextCursor(page.PageInfo)
+		it.done = !ok
+	}
+	it.value, it.nodes = it.nodes[0], it.nodes[1:]
+	return true
+}
+
+// Value returns the current node.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Cursor returns the cursor the next page is fetched from. Pass it to
+// WithCursor to resume the traversal once the nodes already fetched have been
+// processed. It is nil once the last page has been fetched.
+func (it *Iterator[T]) Cursor() *string {
+	if it.done {
+		return nil
+	}
+	return it.cursor
+}
+
+func reversed[T any](nodes []T) []T {
+	r := make([]T, len(nodes))
+	for i, node := range nodes {
+		r[len(nodes)-1-i] = node
+	}
+	return r
+}
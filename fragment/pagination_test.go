@@ -0,0 +1,145 @@
+package fragment
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// getMockFetch returns a FetchPage over the integers 0 to size-1, whose
+// cursors are the integers themselves. It records the requests it receives.
+func getMockFetch(size int, requests *[]PageRequest) FetchPage[int] {
+	return func(_ context.Context, req PageRequest) (*Page[int], error) {
+		*requests = append(*requests, req)
+		first := 3
+		if req.First != nil {
+			first = *req.First
+		}
+		start, end := 0, first
+		if req.After != nil {
+			after, _ := strconv.Atoi(*req.After)
+			start, end = after+1, after+1+first
+		}
+		if req.Before != nil {
+			before, _ := strconv.Atoi(*req.Before)
+			start, end = before-first, before
+		}
+		start, end = max(start, 0), min(end, size)
+
+		page := &Page[int]{PageInfo: PageInfo{HasPreviousPage: start > 0, HasNextPage: end < size}}
+		for i := start; i < end; i++ {
+			page.Nodes = append(page.Nodes, i)
+		}
+		if len(page.Nodes) > 0 {
+			startCursor, endCursor := strconv.Itoa(start), strconv.Itoa(end-1)
+			page.PageInfo.StartCursor, page.PageInfo.EndCursor = &startCursor, &endCursor
+		}
+		return page, nil
+	}
+}
+
+func collect(it *Iterator[int]) []int {
+	var nodes []int
+	for it.Next() {
+		nodes = append(nodes, it.Value())
+	}
+	return nodes
+}
+
+func TestIterator(t *testing.T) {
+	var requests []PageRequest
+	it := NewIterator(context.Background(), getMockFetch(7, &requests), WithPageSize(2))
+
+	if nodes := collect(it); !reflect.DeepEqual(nodes, []int{0, 1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Expected nodes 0 to 6, got %v", nodes)
+	}
+	if it.Err() != nil {
+		t.Errorf("Got error from iterator: %s", it.Err())
+	}
+	if len(requests) != 4 {
+		t.Errorf("Expected 4 requests, got %d", len(requests))
+	}
+	for _, req := range requests {
+		if req.First == nil || *req.First != 2 {
+			t.Errorf("Expected page size 2, got %v", req.First)
+		}
+	}
+	if it.Cursor() != nil {
+		t.Errorf("Expected no cursor at the end, got %s", *it.Cursor())
+	}
+}
+
+func TestIteratorBackward(t *testing.T) {
+	var requests []PageRequest
+	it := NewIterator(context.Background(), getMockFetch(10, &requests), WithPageSize(3), WithCursor("7"), Backward())
+
+	if nodes := collect(it); !reflect.DeepEqual(nodes, []int{6, 5, 4, 3, 2, 1, 0}) {
+		t.Errorf("Expected nodes 6 to 0, got %v", nodes)
+	}
+	for _, req := range requests {
+		if req.After != nil || req.Before == nil {
+			t.Errorf("Expected only before cursors, got %+v", req)
+		}
+	}
+}
+
+func TestIteratorResume(t *testing.T) {
+	var requests []PageRequest
+	it := NewIterator(context.Background(), getMockFetch(6, &requests))
+	for i := 0; i < 3; i++ {
+		it.Next()
+	}
+	cursor := it.Cursor()
+	if cursor == nil || *cursor != "2" {
+		t.Fatalf("Expected cursor 2, got %v", cursor)
+	}
+
+	resumed := NewIterator(context.Background(), getMockFetch(6, &requests), WithCursor(*cursor))
+	if nodes := collect(resumed); !reflect.DeepEqual(nodes, []int{3, 4, 5}) {
+		t.Errorf("Expected nodes 3 to 5, got %v", nodes)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	fetchErr := errors.New("request failed")
+	calls := 0
+	it := NewIterator(context.Background(), func(_ context.Context, req PageRequest) (*Page[int], error) {
+		calls++
+		if calls > 1 {
+			return nil, fetchErr
+		}
+		cursor := "0"
+		return &Page[int]{Nodes: []int{0}, PageInfo: PageInfo{HasNextPage: true, EndCursor: &cursor}}, nil
+	})
+
+	if nodes := collect(it); !reflect.DeepEqual(nodes, []int{0}) {
+		t.Errorf("Expected node 0, got %v", nodes)
+	}
+	if it.Err() != fetchErr {
+		t.Errorf("Expected fetch error, got %v", it.Err())
+	}
+	if it.Next() {
+		t.Errorf("Expected iteration to stop after an error")
+	}
+}
+
+func TestIteratorCancel(t *testing.T) {
+	var requests []PageRequest
+	ctx, cancel := context.WithCancel(context.Background())
+	it := NewIterator(ctx, getMockFetch(7, &requests), WithPageSize(2))
+
+	it.Next()
+	it.Next()
+	cancel()
+	if it.Next() {
+		t.Errorf("Expected iteration to stop once its context is done")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", it.Err())
+	}
+	if len(requests) != 1 {
+		t.Errorf("Expected 1 request, got %d", len(requests))
+	}
+}
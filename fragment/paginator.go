@@ -0,0 +1,170 @@
+package fragment
+
+import (
+	"context"
+	"errors"
+)
+
+// errPaginatorClosed stops a Paginator that was closed by its consumer.
+var errPaginatorClosed = errors.New("paginator closed")
+
+// Paginator fetches the pages of a connection in the background, so that the
+// next page is fetched while the current one is processed. It holds up to
+// WithBuffer fetched pages ahead of the consumer, and fetches one more.
+//
+//	pages := fragment.NewPaginator(ctx, queries.LedgerEntriesPages(ctx, ledgerIk, nil),
+//		fragment.WithCursor(savedCursor),
+//		fragment.WithCheckpoint(saveCursor))
+//	defer pages.Close()
+//	for pages.Next() {
+//		for _, entry := range pages.Page().Nodes {
+//			...
+//		}
+//	}
+//	if err := pages.Err(); err != nil {
+//		...
+//	}
+//
+// A Paginator must be used by a single goroutine.
+type Paginator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   *pageOptions
+
+	pages chan *Page[T]
+	// The error that stopped the fetching goroutine. It is set before pages
+	// is closed.
+	fetchErr error
+	// done is closed once the fetching goroutine has returned.
+	done chan struct{}
+
+	page *Page[T]
+	// The cursor after the last page returned by Next, which is kept after
+	// the traversal stops.
+	cursor *string
+	err    error
+}
+
+// NewPaginator returns a Paginator over the pages returned by fetch. It stops
+// fetching when ctx is done or Close is called, cancelling the context passed
+// to fetch, so that a request in flight is aborted.
+func NewPaginator[T any](ctx context.Context, fetch FetchPage[T], opts ...PageOption) *Paginator[T] {
+	o := newPageOptions(opts)
+	if o.buffer < 0 {
+		o.buffer = 0
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	p := &Paginator[T]{
+		ctx:    ctx,
+		cancel: cancel,
+		opts:   o,
+		pages:  make(chan *Page[T], o.buffer),
+		done:   make(chan struct{}),
+	}
+	go p.run(fetch)
+	return p
+}
+
+func (p *Paginator[T]) run(fetch FetchPage[T]) {
+	defer close(p.done)
+	defer close(p.pages)
+
+	cursor := p.opts.cursor
+	for {
+		if err := p.ctx.Err(); err != nil {
+			p.fetchErr = err
+			return
+		}
+		page, err := fetch(p.ctx, p.opts.request(cursor))
+		if err != nil {
+			p.fetchErr = err
+			return
+		}
+		select {
+		case p.pages <- orient(p.opts, page):
+		case <-p.ctx.Done():
+			p.fetchErr = p.ctx.Err()
+			return
+		}
+
+		var ok bool
+		if cursor, ok = p.opts.nextCursor(page.PageInfo); !ok {
+			return
+		}
+	}
+}
+
+// Next advances to the next page, waiting for it to be fetched if needed.
+// Before it does, the cursor after the current page is passed to the
+// WithCheckpoint function. It returns false at the end of the connection, or
+// when the traversal stopped early.
+func (p *Paginator[T]) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	if err := p.saveCheckpoint(); err != nil {
+		p.stop(err)
+		return false
+	}
+
+	page, ok := <-p.pages
+	if !ok {
+		p.page = nil
+		p.stop(p.fetchErr)
+		return false
+	}
+	p.page = page
+	if p.opts.backward {
+		p.cursor = page.PageInfo.StartCursor
+	} else {
+		p.cursor = page.PageInfo.EndCursor
+	}
+	return true
+}
+
+// Page returns the current page.
+func (p *Paginator[T]) Page() *Page[T] {
+	return p.page
+}
+
+// Cursor returns the cursor that resumes the traversal after the last page
+// returned by Next, or nil before the first page. It's kept once the
+// traversal stops, so that a failed traversal can be resumed from it with
+// WithCursor.
+func (p *Paginator[T]) Cursor() *string {
+	return p.cursor
+}
+
+// Err returns the error that stopped the traversal, if any.
+func (p *Paginator[T]) Err() error {
+	if p.err == errPaginatorClosed {
+		return nil
+	}
+	return p.err
+}
+
+// Close stops fetching pages, and waits for a page being fetched to be
+// aborted. Call it if you stop iterating before Next returns false.
+func (p *Paginator[T]) Close() {
+	p.stop(errPaginatorClosed)
+	<-p.done
+}
+
+func (p *Paginator[T]) saveCheckpoint() error {
+	if p.opts.checkpoint == nil {
+		return nil
+	}
+	if cursor := p.Cursor(); cursor != nil {
+		return p.opts.checkpoint(*cursor)
+	}
+	return nil
+}
+
+// stop records the error that stopped the traversal, if it hasn't already
+// stopped, and stops fetching pages.
+func (p *Paginator[T]) stop(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+	p.cancel()
+}
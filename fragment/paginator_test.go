@@ -0,0 +1,186 @@
+package fragment
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// getBlockingFetch returns a FetchPage over the integers 0 to size-1 that
+// reports every request on fetched, and waits on release before returning
+// each page, or until its context is done.
+func getBlockingFetch(size int, fetched chan<- PageRequest, release <-chan struct{}) FetchPage[int] {
+	var requests []PageRequest
+	fetch := getMockFetch(size, &requests)
+	return func(ctx context.Context, req PageRequest) (*Page[int], error) {
+		fetched <- req
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return fetch(ctx, req)
+	}
+}
+
+func expectFetch(t *testing.T, fetched <-chan PageRequest) {
+	t.Helper()
+	select {
+	case <-fetched:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a page to be fetched")
+	}
+}
+
+func expectNoFetch(t *testing.T, fetched <-chan PageRequest) {
+	t.Helper()
+	select {
+	case req := <-fetched:
+		t.Fatalf("Expected no page to be fetched, got %+v", req)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPaginatorPrefetch(t *testing.T) {
+	fetched := make(chan PageRequest, 10)
+	release := make(chan struct{})
+	close(release)
+	p := NewPaginator(context.Background(), getBlockingFetch(100, fetched, release), WithPageSize(2), WithBuffer(2))
+	defer p.Close()
+
+	if !p.Next() {
+		t.Fatalf("Expected a page, got error %v", p.Err())
+	}
+	// While the first page is processed, the next two are buffered and a
+	// third is fetched, waiting for room in the buffer.
+	for i := 0; i < 4; i++ {
+		expectFetch(t, fetched)
+	}
+	expectNoFetch(t, fetched)
+
+	if !p.Next() || !reflect.DeepEqual(p.Page().Nodes, []int{2, 3}) {
+		t.Errorf("Expected nodes 2 and 3, got %v", p.Page().Nodes)
+	}
+	expectFetch(t, fetched)
+	expectNoFetch(t, fetched)
+}
+
+func TestPaginatorCancel(t *testing.T) {
+	fetched := make(chan PageRequest, 10)
+	release := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewPaginator(ctx, getBlockingFetch(100, fetched, release), WithBuffer(0))
+
+	expectFetch(t, fetched)
+	cancel()
+	close(release)
+	for p.Next() {
+	}
+	if !errors.Is(p.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", p.Err())
+	}
+}
+
+func TestPaginatorCloseAbortsFetch(t *testing.T) {
+	fetched := make(chan PageRequest, 10)
+	aborted := make(chan struct{})
+	blocking := getBlockingFetch(100, fetched, make(chan struct{}))
+	p := NewPaginator(context.Background(), func(ctx context.Context, req PageRequest) (*Page[int], error) {
+		defer close(aborted)
+		return blocking(ctx, req)
+	})
+	expectFetch(t, fetched)
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatalf("Expected Close to abort the blocked fetch")
+	}
+	select {
+	case <-aborted:
+	default:
+		t.Errorf("Expected the fetch to have returned when Close returns")
+	}
+	if p.Next() || p.Err() != nil {
+		t.Errorf("Expected a closed paginator to stop without error, got %v", p.Err())
+	}
+}
+
+func TestPaginatorCursorAfterError(t *testing.T) {
+	var requests []PageRequest
+	fetch := getMockFetch(7, &requests)
+	fetchErr := errors.New("request failed")
+	p := NewPaginator(context.Background(), func(ctx context.Context, req PageRequest) (*Page[int], error) {
+		if len(requests) > 0 {
+			return nil, fetchErr
+		}
+		return fetch(ctx, req)
+	}, WithPageSize(3))
+	defer p.Close()
+
+	for p.Next() {
+	}
+	if p.Err() != fetchErr {
+		t.Errorf("Expected fetch error, got %v", p.Err())
+	}
+	if cursor := p.Cursor(); cursor == nil || *cursor != "2" {
+		t.Errorf("Expected the traversal to resume after cursor 2, got %v", cursor)
+	}
+}
+
+func TestPaginatorCheckpoint(t *testing.T) {
+	var requests []PageRequest
+	var checkpoints []string
+	save := func(cursor string) error {
+		checkpoints = append(checkpoints, cursor)
+		return nil
+	}
+	p := NewPaginator(context.Background(), getMockFetch(7, &requests), WithPageSize(3), WithCheckpoint(save))
+
+	// Stop after processing the first page, as if interrupted.
+	p.Next()
+	p.Next()
+	p.Close()
+	if !reflect.DeepEqual(checkpoints, []string{"2"}) {
+		t.Fatalf("Expected checkpoint 2, got %v", checkpoints)
+	}
+
+	var nodes []int
+	var resumedRequests []PageRequest
+	resumed := NewPaginator(context.Background(), getMockFetch(7, &resumedRequests),
+		WithPageSize(3), WithCursor(checkpoints[0]), WithCheckpoint(save))
+	for resumed.Next() {
+		nodes = append(nodes, resumed.Page().Nodes...)
+	}
+	if resumed.Err() != nil {
+		t.Errorf("Got error from paginator: %s", resumed.Err())
+	}
+	if !reflect.DeepEqual(nodes, []int{3, 4, 5, 6}) {
+		t.Errorf("Expected nodes 3 to 6, got %v", nodes)
+	}
+	if !reflect.DeepEqual(checkpoints, []string{"2", "5", "6"}) {
+		t.Errorf("Expected checkpoints 2, 5 and 6, got %v", checkpoints)
+	}
+}
+
+func TestPaginatorCheckpointError(t *testing.T) {
+	var requests []PageRequest
+	saveErr := errors.New("save failed")
+	p := NewPaginator(context.Background(), getMockFetch(7, &requests),
+		WithCheckpoint(func(string) error { return saveErr }))
+
+	p.Next()
+	if p.Next() {
+		t.Errorf("Expected traversal to stop when a checkpoint can't be saved")
+	}
+	if p.Err() != saveErr {
+		t.Errorf("Expected save error, got %v", p.Err())
+	}
+}
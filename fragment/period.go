@@ -0,0 +1,307 @@
+package fragment
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Granularity is the length of a Period, or of the period a LastMoment ends.
+type Granularity int
+
+const (
+	GranularityYear Granularity = iota + 1
+	GranularityQuarter
+	GranularityMonth
+	GranularityDay
+	GranularityHour
+)
+
+// String returns the name of g, such as "month".
+func (g Granularity) String() string {
+	switch g {
+	case GranularityYear:
+		return "year"
+	case GranularityQuarter:
+		return "quarter"
+	case GranularityMonth:
+		return "month"
+	case GranularityDay:
+		return "day"
+	case GranularityHour:
+		return "hour"
+	}
+	return fmt.Sprintf("Granularity(%d)", int(g))
+}
+
+// Period is a year, quarter, month, day or hour, in the balanceUTCOffset of a
+// Ledger. It is bound to the Period scalar of the Fragment API, and encodes as
+// "2024", "2024-Q1", "2024-03", "2024-03-15" or "2024-03-15T10", depending on
+// its Granularity.
+//
+// Fields finer than the Granularity are ignored. Build a Period with
+// YearPeriod, QuarterPeriod, MonthPeriod, DayPeriod or HourPeriod.
+type Period struct {
+	Granularity Granularity
+	Year        int
+	Quarter     int
+	Month       time.Month
+	Day         int
+	Hour        int
+}
+
+// YearPeriod returns the Period of a year.
+func YearPeriod(year int) Period {
+	return Period{Granularity: GranularityYear, Year: year}
+}
+
+// QuarterPeriod returns the Period of a quarter, from 1 to 4, of a year.
+func QuarterPeriod(year int, quarter int) Period {
+	return Period{Granularity: GranularityQuarter, Year: year, Quarter: quarter}
+}
+
+// MonthPeriod returns the Period of a month.
+func MonthPeriod(year int, month time.Month) Period {
+	return Period{Granularity: GranularityMonth, Year: year, Month: month}
+}
+
+// DayPeriod returns the Period of a day.
+func DayPeriod(date Date) Period {
+	return Period{Granularity: GranularityDay, Year: date.Year, Month: date.Month, Day: date.Day}
+}
+
+// HourPeriod returns the Period of an hour, from 0 to 23, of a day.
+func HourPeriod(date Date, hour int) Period {
+	return Period{Granularity: GranularityHour, Year: date.Year, Month: date.Month, Day: date.Day, Hour: hour}
+}
+
+// ParsePeriod parses a Period such as "2024-Q1".
+func ParsePeriod(s string) (Period, error) {
+	p, ok := parsePeriod(s)
+	if !ok || p.Validate() != nil {
+		return Period{}, fmt.Errorf("Invalid Period %q", s)
+	}
+	return p, nil
+}
+
+// Validate returns an error if p isn't a Period that exists, such as the
+// 13th month or the 30th of February.
+func (p Period) Validate() error {
+	return p.validate("Period")
+}
+
+func (p Period) validate(kind string) error {
+	if p.Granularity < GranularityYear || p.Granularity > GranularityHour {
+		return fmt.Errorf("Invalid %s: unknown granularity %d", kind, int(p.Granularity))
+	}
+	if p.Year < 0 || p.Year > 9999 {
+		return fmt.Errorf("Invalid %s: year %d is out of range", kind, p.Year)
+	}
+	if p.Granularity == GranularityQuarter && (p.Quarter < 1 || p.Quarter > 4) {
+		return fmt.Errorf("Invalid %s: quarter %d is out of range", kind, p.Quarter)
+	}
+	if p.Granularity >= GranularityMonth && (p.Month < time.January || p.Month > time.December) {
+		return fmt.Errorf("Invalid %s: month %d is out of range", kind, p.Month)
+	}
+	if p.Granularity >= GranularityDay && !NewDate(p.Year, p.Month, p.Day).IsValid() {
+		return fmt.Errorf("Invalid %s: %04d-%02d-%02d isn't a date", kind, p.Year, p.Month, p.Day)
+	}
+	if p.Granularity == GranularityHour && (p.Hour < 0 || p.Hour > 23) {
+		return fmt.Errorf("Invalid %s: hour %d is out of range", kind, p.Hour)
+	}
+	return nil
+}
+
+// LastMoment returns the last moment of p. The last moment of a quarter is
+// that of its last month.
+func (p Period) LastMoment() LastMoment {
+	if p.Granularity == GranularityQuarter {
+		return EndOfMonth(p.Year, time.Month(p.Quarter*3))
+	}
+	return LastMoment{Granularity: p.Granularity, Year: p.Year, Month: p.Month, Day: p.Day, Hour: p.Hour}
+}
+
+// String returns p as "2024", "2024-Q1", "2024-03", "2024-03-15" or
+// "2024-03-15T10", depending on its Granularity.
+func (p Period) String() string {
+	switch p.Granularity {
+	case GranularityYear:
+		return fmt.Sprintf("%04d", p.Year)
+	case GranularityQuarter:
+		return fmt.Sprintf("%04d-Q%d", p.Year, p.Quarter)
+	case GranularityMonth:
+		return fmt.Sprintf("%04d-%02d", p.Year, p.Month)
+	case GranularityDay:
+		return fmt.Sprintf("%04d-%02d-%02d", p.Year, p.Month, p.Day)
+	case GranularityHour:
+		return fmt.Sprintf("%04d-%02d-%02dT%02d", p.Year, p.Month, p.Day, p.Hour)
+	}
+	return ""
+}
+
+// MarshalJSON encodes p as a JSON string such as "2024-Q1". It returns an
+// error if p isn't valid, so that it's caught before a request is sent.
+func (p Period) MarshalJSON() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON decodes a JSON string such as "2024-Q1".
+func (p *Period) UnmarshalJSON(data []byte) error {
+	s, ok, err := unmarshalScalar(data)
+	if !ok || err != nil {
+		return err
+	}
+	parsed, err := ParsePeriod(s)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// LastMoment is the last moment of a year, month, day or hour, in the
+// balanceUTCOffset of a Ledger. It is bound to the LastMoment scalar of the
+// Fragment API, and encodes as "2024", "2024-03", "2024-03-15" or
+// "2024-03-15T10", depending on its Granularity.
+//
+// Fields finer than the Granularity are ignored. Build a LastMoment with
+// EndOfYear, EndOfMonth, EndOfDay or EndOfHour.
+type LastMoment struct {
+	Granularity Granularity
+	Year        int
+	Month       time.Month
+	Day         int
+	Hour        int
+}
+
+// EndOfYear returns the last moment of a year.
+func EndOfYear(year int) LastMoment {
+	return LastMoment{Granularity: GranularityYear, Year: year}
+}
+
+// EndOfMonth returns the last moment of a month.
+func EndOfMonth(year int, month time.Month) LastMoment {
+	return LastMoment{Granularity: GranularityMonth, Year: year, Month: month}
+}
+
+// EndOfDay returns the last moment of a day.
+func EndOfDay(date Date) LastMoment {
+	return LastMoment{Granularity: GranularityDay, Year: date.Year, Month: date.Month, Day: date.Day}
+}
+
+// EndOfHour returns the last moment of an hour, from 0 to 23, of a day.
+func EndOfHour(date Date, hour int) LastMoment {
+	return LastMoment{Granularity: GranularityHour, Year: date.Year, Month: date.Month, Day: date.Day, Hour: hour}
+}
+
+// ParseLastMoment parses a LastMoment such as "2024-03".
+func ParseLastMoment(s string) (LastMoment, error) {
+	p, ok := parsePeriod(s)
+	if !ok || p.Granularity == GranularityQuarter || p.Validate() != nil {
+		return LastMoment{}, fmt.Errorf("Invalid LastMoment %q", s)
+	}
+	return p.LastMoment(), nil
+}
+
+// Validate returns an error if m isn't the end of a period that exists, such
+// as the 13th month or the 30th of February.
+func (m LastMoment) Validate() error {
+	if m.Granularity == GranularityQuarter {
+		return fmt.Errorf("Invalid LastMoment: granularity quarter isn't supported")
+	}
+	return m.Period().validate("LastMoment")
+}
+
+// Period returns the period m is the last moment of.
+func (m LastMoment) Period() Period {
+	return Period{Granularity: m.Granularity, Year: m.Year, Month: m.Month, Day: m.Day, Hour: m.Hour}
+}
+
+// String returns m as "2024", "2024-03", "2024-03-15" or "2024-03-15T10",
+// depending on its Granularity.
+func (m LastMoment) String() string {
+	if m.Granularity == GranularityQuarter {
+		return ""
+	}
+	return m.Period().String()
+}
+
+// MarshalJSON encodes m as a JSON string such as "2024-03". It returns an
+// error if m isn't valid, so that it's caught before a request is sent.
+func (m LastMoment) MarshalJSON() ([]byte, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON decodes a JSON string such as "2024-03".
+func (m *LastMoment) UnmarshalJSON(data []byte) error {
+	s, ok, err := unmarshalScalar(data)
+	if !ok || err != nil {
+		return err
+	}
+	parsed, err := ParseLastMoment(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// parsePeriod parses the fields of s, without validating them.
+func parsePeriod(s string) (Period, bool) {
+	date, hour, hasHour := strings.Cut(s, "T")
+	parts := strings.Split(date, "-")
+	p := Period{Granularity: GranularityYear}
+
+	var ok bool
+	if p.Year, ok = parseDigits(parts[0], 4); !ok {
+		return Period{}, false
+	}
+	switch {
+	case len(parts) == 2 && strings.HasPrefix(parts[1], "Q") && !hasHour:
+		p.Granularity = GranularityQuarter
+		p.Quarter, ok = parseDigits(parts[1][1:], 1)
+	case len(parts) == 2 && !hasHour:
+		p.Granularity = GranularityMonth
+		var month int
+		month, ok = parseDigits(parts[1], 2)
+		p.Month = time.Month(month)
+	case len(parts) == 3:
+		p.Granularity = GranularityDay
+		var month int
+		if month, ok = parseDigits(parts[1], 2); !ok {
+			return Period{}, false
+		}
+		p.Month = time.Month(month)
+		if p.Day, ok = parseDigits(parts[2], 2); ok && hasHour {
+			p.Granularity = GranularityHour
+			p.Hour, ok = parseDigits(hour, 2)
+		}
+	case len(parts) == 1 && !hasHour:
+		ok = true
+	default:
+		ok = false
+	}
+	return p, ok
+}
+
+// parseDigits parses s if it's exactly width decimal digits.
+func parseDigits(s string, width int) (int, bool) {
+	if len(s) != width {
+		return 0, false
+	}
+	n := 0
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
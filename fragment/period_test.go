@@ -0,0 +1,89 @@
+package fragment
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLastMoment(t *testing.T) {
+	for _, test := range []struct {
+		moment   LastMoment
+		expected string
+	}{
+		{EndOfYear(2024), "2024"},
+		{EndOfMonth(2024, time.March), "2024-03"},
+		{EndOfDay(NewDate(2024, time.February, 29)), "2024-02-29"},
+		{EndOfHour(NewDate(2024, time.March, 15), 23), "2024-03-15T23"},
+	} {
+		if err := test.moment.Validate(); err != nil {
+			t.Errorf("Got error validating %s: %s", test.expected, err)
+		}
+		encoded, err := json.Marshal(test.moment)
+		if err != nil || string(encoded) != `"`+test.expected+`"` {
+			t.Errorf("Expected %s to encode, got %s (%v)", test.expected, encoded, err)
+		}
+
+		var decoded LastMoment
+		if err := json.Unmarshal(encoded, &decoded); err != nil || decoded != test.moment {
+			t.Errorf("Expected %s to round-trip through JSON, got %+v (%v)", test.expected, decoded, err)
+		}
+	}
+
+	for _, moment := range []LastMoment{
+		{},
+		EndOfMonth(2024, 13),
+		EndOfDay(NewDate(2023, time.February, 29)),
+		EndOfHour(NewDate(2024, time.March, 15), 24),
+		{Granularity: GranularityQuarter, Year: 2024},
+	} {
+		if moment.Validate() == nil {
+			t.Errorf("Expected %+v to be invalid", moment)
+		}
+		if _, err := json.Marshal(moment); err == nil {
+			t.Errorf("Expected an error encoding %+v", moment)
+		}
+	}
+
+	for _, value := range []string{"", "24", "2024-3", "2024-13", "2024-Q1", "2023-02-29", "2024-03-15T24", "2024-03T10", "2024-03-15T"} {
+		if _, err := ParseLastMoment(value); err == nil {
+			t.Errorf("Expected an error parsing %q", value)
+		}
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	for _, test := range []struct {
+		period   Period
+		expected string
+		end      string
+	}{
+		{YearPeriod(2024), "2024", "2024"},
+		{QuarterPeriod(2024, 1), "2024-Q1", "2024-03"},
+		{MonthPeriod(2024, time.December), "2024-12", "2024-12"},
+		{DayPeriod(NewDate(2024, time.March, 15)), "2024-03-15", "2024-03-15"},
+		{HourPeriod(NewDate(2024, time.March, 15), 0), "2024-03-15T00", "2024-03-15T00"},
+	} {
+		encoded, err := json.Marshal(test.period)
+		if err != nil || string(encoded) != `"`+test.expected+`"` {
+			t.Errorf("Expected %s to encode, got %s (%v)", test.expected, encoded, err)
+		}
+
+		var decoded Period
+		if err := json.Unmarshal(encoded, &decoded); err != nil || decoded != test.period {
+			t.Errorf("Expected %s to round-trip through JSON, got %+v (%v)", test.expected, decoded, err)
+		}
+		if end := test.period.LastMoment().String(); end != test.end {
+			t.Errorf("Expected %s to end at %s, got %s", test.expected, test.end, end)
+		}
+	}
+
+	if QuarterPeriod(2024, 5).Validate() == nil {
+		t.Errorf("Expected quarter 5 to be invalid")
+	}
+	for _, value := range []string{"2024-Q", "2024-Q0", "2024-Q12", "2024-Q1T10", "2024-03-15T10-00"} {
+		if _, err := ParsePeriod(value); err == nil {
+			t.Errorf("Expected an error parsing %q", value)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package fragment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateTime is a moment in time. It is bound to the DateTime scalar of the
+// Fragment API, and encodes as an ISO-8601 timestamp such as
+// "1968-01-01T16:45:00Z".
+type DateTime struct {
+	time.Time
+}
+
+// NewDateTime returns a DateTime at t.
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{t}
+}
+
+// ParseDateTime parses an ISO-8601 timestamp, such as "1968-01-01T16:45:00Z".
+func ParseDateTime(s string) (DateTime, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return DateTime{}, fmt.Errorf("Invalid DateTime %q", s)
+	}
+	return DateTime{t}, nil
+}
+
+// String returns d as an ISO-8601 timestamp.
+func (d DateTime) String() string {
+	return d.Time.Format(time.RFC3339Nano)
+}
+
+// MarshalJSON encodes d as a JSON string holding an ISO-8601 timestamp.
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a JSON string holding an ISO-8601 timestamp.
+func (d *DateTime) UnmarshalJSON(data []byte) error {
+	s, ok, err := unmarshalScalar(data)
+	if !ok || err != nil {
+		return err
+	}
+	parsed, err := ParseDateTime(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Date is a calendar date, without a time or time zone. It is bound to the
+// Date scalar of the Fragment API, and encodes as "2006-01-02".
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the Date year-month-day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// DateOf returns the Date on which t falls, in t's location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a date such as "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("Invalid Date %q", s)
+	}
+	return DateOf(t), nil
+}
+
+// IsValid reports whether d is a date that exists, so that 2024-02-30 isn't.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days after d.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Before reports whether d is before other.
+func (d Date) Before(other Date) bool {
+	return d.In(time.UTC).Before(other.In(time.UTC))
+}
+
+// After reports whether d is after other.
+func (d Date) After(other Date) bool {
+	return d.In(time.UTC).After(other.In(time.UTC))
+}
+
+// String returns d as "2006-01-02".
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MarshalJSON encodes d as a JSON string such as "2006-01-02".
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a JSON string such as "2006-01-02".
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, ok, err := unmarshalScalar(data)
+	if !ok || err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// unmarshalScalar decodes a JSON string. It returns false if data is null.
+func unmarshalScalar(data []byte) (string, bool, error) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return "", false, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", false, err
+	}
+	return s, true, nil
+}
@@ -0,0 +1,70 @@
+package fragment
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateTimeJSON(t *testing.T) {
+	var entry struct {
+		Posted  DateTime  `json:"posted"`
+		Created *DateTime `json:"created"`
+	}
+	if err := json.Unmarshal([]byte(`{"posted":"1968-01-01T16:45:00Z","created":"2024-03-15T10:30:00.5+02:00"}`), &entry); err != nil {
+		t.Fatalf("Got error from Unmarshal: %s", err)
+	}
+	if !entry.Posted.Equal(time.Date(1968, 1, 1, 16, 45, 0, 0, time.UTC)) {
+		t.Errorf("Expected posted to be decoded, got %s", entry.Posted)
+	}
+	if !entry.Created.Equal(time.Date(2024, 3, 15, 8, 30, 0, 5e8, time.UTC)) {
+		t.Errorf("Expected created to be decoded, got %s", entry.Created)
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Got error from Marshal: %s", err)
+	}
+	if expected := `{"posted":"1968-01-01T16:45:00Z","created":"2024-03-15T10:30:00.5+02:00"}`; string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"posted":"1968-01-01"}`), &entry); err == nil {
+		t.Errorf("Expected an error decoding a date as a DateTime")
+	}
+}
+
+func TestDate(t *testing.T) {
+	d, err := ParseDate("2024-02-29")
+	if err != nil {
+		t.Fatalf("Got error from ParseDate: %s", err)
+	}
+	if d != NewDate(2024, time.February, 29) {
+		t.Errorf("Expected 2024-02-29, got %s", d)
+	}
+	if next := d.AddDays(1); next.String() != "2024-03-01" || !next.After(d) {
+		t.Errorf("Expected the day after %s to be 2024-03-01, got %s", d, next)
+	}
+	if NewDate(2023, time.February, 29).IsValid() {
+		t.Errorf("Expected 2023-02-29 to be invalid")
+	}
+	if _, err := ParseDate("2023-02-29"); err == nil {
+		t.Errorf("Expected an error parsing 2023-02-29")
+	}
+
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	if got := DateOf(time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC).In(loc)); got.String() != "2024-02-29" {
+		t.Errorf("Expected the date in UTC-5 to be 2024-02-29, got %s", got)
+	}
+
+	var filter struct {
+		In []Date `json:"in"`
+	}
+	if err := json.Unmarshal([]byte(`{"in":["2024-01-31","2024-12-01"]}`), &filter); err != nil {
+		t.Fatalf("Got error from Unmarshal: %s", err)
+	}
+	encoded, _ := json.Marshal(filter)
+	if expected := `{"in":["2024-01-31","2024-12-01"]}`; string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+}
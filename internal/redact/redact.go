@@ -0,0 +1,103 @@
+// Package redact removes credentials from log records.
+package redact
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces values that are removed from log records.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the attribute keys whose values are always redacted,
+// compared case-insensitively with underscores and dashes removed.
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"clientsecret":  true,
+	"secret":        true,
+	"accesstoken":   true,
+	"password":      true,
+}
+
+// credentials matches the credentials of Authorization header values.
+var credentials = regexp.MustCompile(`(?i)\b(Bearer|Basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+
+// IsSensitiveKey reports whether values with key are redacted.
+func IsSensitiveKey(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	return sensitiveKeys[key]
+}
+
+// String returns s with any bearer or basic credentials redacted.
+func String(s string) string {
+	return credentials.ReplaceAllString(s, "$1 "+Redacted)
+}
+
+// Attr returns attr with sensitive values redacted.
+func Attr(attr slog.Attr) slog.Attr {
+	if IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, String(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, a := range group {
+			attrs[i] = Attr(a)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(attrs...)}
+	case slog.KindAny:
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, String(err.Error()))
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+type handler struct {
+	slog.Handler
+}
+
+// Handler returns a handler that redacts sensitive values before passing
+// records on to h.
+func Handler(h slog.Handler) slog.Handler {
+	if _, ok := h.(handler); ok {
+		return h
+	}
+	return handler{h}
+}
+
+func (h handler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, String(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(Attr(attr))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = Attr(attr)
+	}
+	return handler{h.Handler.WithAttrs(redacted)}
+}
+
+func (h handler) WithGroup(name string) slog.Handler {
+	return handler{h.Handler.WithGroup(name)}
+}
+
+// Logger returns a logger that redacts sensitive values before passing
+// records on to the handler of logger. It returns nil if logger is nil.
+func Logger(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return nil
+	}
+	return slog.New(Handler(logger.Handler()))
+}
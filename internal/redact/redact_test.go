@@ -0,0 +1,29 @@
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Logger(slog.New(slog.NewTextHandler(&buf, nil))).With("client_secret", "secret-1")
+
+	logger.Info("Sending Authorization: Bearer token-2",
+		"Authorization", "Basic dXNlcjpzZWNyZXQ=",
+		"error", errors.New("rejected Bearer token-3"),
+		slog.Group("params", "clientSecret", "secret-4", "scope", "scope-5"))
+
+	output := buf.String()
+	for _, secret := range []string{"secret-1", "token-2", "dXNlcjpzZWNyZXQ", "token-3", "secret-4"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %s to be redacted from %s", secret, output)
+		}
+	}
+	if !strings.Contains(output, "scope-5") {
+		t.Errorf("Expected scope-5 not to be redacted from %s", output)
+	}
+}
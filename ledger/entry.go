@@ -0,0 +1,253 @@
+// Package ledger builds Ledger Entries whose lines are defined at runtime,
+// and checks that they balance before they're posted.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fragment-dev/fragment-go/auth"
+	"github.com/fragment-dev/fragment-go/fragment"
+	"github.com/fragment-dev/fragment-go/money"
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+// ErrUnbalanced is returned for entries whose debits and credits differ in
+// some currency.
+var ErrUnbalanced = errors.New("Entry doesn't balance")
+
+// AccountTypes maps Ledger Account paths to their types. A Ledger Account
+// takes the type of its longest path prefix in the map, so the top-level
+// accounts of a schema, whose types their children inherit, are usually
+// enough:
+//
+//	ledger.AccountTypes{
+//		"assets-root": queries.LedgerAccountTypesAsset,
+//		"liabilities": queries.LedgerAccountTypesLiability,
+//	}
+type AccountTypes map[string]queries.LedgerAccountTypes
+
+// Lookup returns the type of the Ledger Account at path.
+func (t AccountTypes) Lookup(path string) (queries.LedgerAccountTypes, bool) {
+	for {
+		if accountType, ok := t[path]; ok {
+			return accountType, true
+		}
+		i := strings.LastIndex(path, "/")
+		if i < 0 {
+			return "", false
+		}
+		path = path[:i]
+	}
+}
+
+// LineOption sets optional fields of a Ledger Line.
+type LineOption func(*queries.LedgerLineInput)
+
+// WithKey sets the key of a line, which lines can be filtered by.
+func WithKey(key string) LineOption {
+	return func(line *queries.LedgerLineInput) {
+		line.Key = &key
+	}
+}
+
+// WithDescription sets the description of a line, in place of the entry's.
+func WithDescription(description string) LineOption {
+	return func(line *queries.LedgerLineInput) {
+		line.Description = &description
+	}
+}
+
+// WithTx sets the transaction a line reconciles, for use with Reconcile.
+func WithTx(tx queries.TxMatchInput) LineOption {
+	return func(line *queries.LedgerLineInput) {
+		line.Tx = &tx
+	}
+}
+
+type line struct {
+	path   string
+	amount money.Money
+	debit  bool
+	opts   []LineOption
+}
+
+// EntryBuilder builds the lines, tags and groups of a runtime Ledger Entry,
+// for AddLedgerEntryRuntime and ReconcileTxRuntime:
+//
+//	response, err := ledger.NewEntryBuilder("your-ledger-ik", "user_funds_account", types).
+//		Debit("assets-root/bank", amount).
+//		Credit("liabilities/user:user-1/available", amount).
+//		Tag("user", "user-1").
+//		Add(authenticatedContext, ik, nil)
+//
+// Debits increase the balances of asset and expense accounts, and reduce
+// those of liability and income accounts. Credits do the opposite.
+type EntryBuilder struct {
+	ledgerIk  string
+	entryType string
+	types     AccountTypes
+
+	lines  []line
+	tags   []queries.LedgerEntryTagInput
+	groups []queries.LedgerEntryGroupInput
+}
+
+// NewEntryBuilder returns an EntryBuilder for an entry of entryType in the
+// Ledger with ledgerIk, whose accounts have types.
+func NewEntryBuilder(ledgerIk string, entryType string, types AccountTypes) *EntryBuilder {
+	return &EntryBuilder{ledgerIk: ledgerIk, entryType: entryType, types: types}
+}
+
+// Debit adds a line debiting amount to the Ledger Account at path.
+func (b *EntryBuilder) Debit(path string, amount money.Money, opts ...LineOption) *EntryBuilder {
+	b.lines = append(b.lines, line{path: path, amount: amount, debit: true, opts: opts})
+	return b
+}
+
+// Credit adds a line crediting amount to the Ledger Account at path.
+func (b *EntryBuilder) Credit(path string, amount money.Money, opts ...LineOption) *EntryBuilder {
+	b.lines = append(b.lines, line{path: path, amount: amount, debit: false, opts: opts})
+	return b
+}
+
+// Tag adds a tag to the entry.
+func (b *EntryBuilder) Tag(key string, value string) *EntryBuilder {
+	b.tags = append(b.tags, queries.LedgerEntryTagInput{Key: key, Value: value})
+	return b
+}
+
+// Group adds the entry to a Ledger Entry Group.
+func (b *EntryBuilder) Group(key string, value string) *EntryBuilder {
+	b.groups = append(b.groups, queries.LedgerEntryGroupInput{Key: key, Value: value})
+	return b
+}
+
+// Check returns an error if a line's account has no known type, or its amount
+// isn't positive, or if the debits and credits of the entry differ in any
+// currency. An imbalance is reported as ErrUnbalanced.
+func (b *EntryBuilder) Check() error {
+	_, err := b.Lines()
+	return err
+}
+
+// Lines returns the lines of the entry, with amounts signed by their effect
+// on the balances of their accounts, as the Fragment API expects. It returns
+// the same errors as Check.
+func (b *EntryBuilder) Lines() ([]queries.LedgerLineInput, error) {
+	if len(b.lines) == 0 {
+		return nil, fmt.Errorf("You must provide at least one line")
+	}
+
+	var errs []error
+	var totals []*total
+	lines := make([]queries.LedgerLineInput, 0, len(b.lines))
+	for _, l := range b.lines {
+		accountType, ok := b.types.Lookup(l.path)
+		if !ok {
+			errs = append(errs, fmt.Errorf("Type of Ledger Account %s is unknown", l.path))
+			continue
+		}
+		if l.amount.Amount.Sign() <= 0 {
+			errs = append(errs, fmt.Errorf("Amount of line for Ledger Account %s must be positive, got %s", l.path, l.amount))
+			continue
+		}
+		totals = addTotal(totals, l.amount, l.debit)
+
+		amount := l.amount.Amount
+		if l.debit != increasedByDebit(accountType) {
+			amount = amount.Neg()
+		}
+		lines = append(lines, b.lineInput(l, amount))
+	}
+
+	for _, t := range totals {
+		if !t.debits.Amount.Equal(t.credits.Amount) {
+			errs = append(errs, fmt.Errorf("%w: debits total %s, credits total %s", ErrUnbalanced, t.debits, t.credits))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return lines, nil
+}
+
+// Tags returns the tags of the entry.
+func (b *EntryBuilder) Tags() []queries.LedgerEntryTagInput {
+	return b.tags
+}
+
+// Groups returns the groups of the entry.
+func (b *EntryBuilder) Groups() []queries.LedgerEntryGroupInput {
+	return b.groups
+}
+
+// Add checks the entry, then posts it with AddLedgerEntryRuntime.
+func (b *EntryBuilder) Add(ctx auth.AuthenticatedContext, ik string, posted *fragment.DateTime) (*queries.AddLedgerEntryRuntimeResponse, error) {
+	lines, err := b.Lines()
+	if err != nil {
+		return nil, err
+	}
+	return queries.AddLedgerEntryRuntime(ctx, ik, b.entryType, b.ledgerIk, posted, lines, b.tags, b.groups)
+}
+
+// Reconcile checks the entry, then posts it with ReconcileTxRuntime. The line
+// of the Linked Ledger Account must set the transaction it reconciles with
+// WithTx.
+func (b *EntryBuilder) Reconcile(ctx auth.AuthenticatedContext) (*queries.ReconcileTxRuntimeResponse, error) {
+	lines, err := b.Lines()
+	if err != nil {
+		return nil, err
+	}
+	return queries.ReconcileTxRuntime(ctx, b.ledgerIk, b.entryType, lines, b.tags, b.groups)
+}
+
+func (b *EntryBuilder) lineInput(l line, amount fragment.Amount) queries.LedgerLineInput {
+	path, ledgerIk, currency := l.path, b.ledgerIk, l.amount.Currency
+	input := queries.LedgerLineInput{
+		Account: queries.LedgerAccountMatchInput{
+			Path:   &path,
+			Ledger: &queries.LedgerMatchInput{Ik: &ledgerIk},
+		},
+		Amount:   &amount,
+		Currency: &currency,
+	}
+	for _, opt := range l.opts {
+		opt(&input)
+	}
+	return input
+}
+
+// increasedByDebit reports whether debits increase the balance of accounts
+// of accountType.
+func increasedByDebit(accountType queries.LedgerAccountTypes) bool {
+	return accountType == queries.LedgerAccountTypesAsset || accountType == queries.LedgerAccountTypesExpense
+}
+
+// total is the sum of the debits and credits of an entry in one currency.
+type total struct {
+	debits  money.Money
+	credits money.Money
+}
+
+func addTotal(totals []*total, amount money.Money, debit bool) []*total {
+	var t *total
+	for _, existing := range totals {
+		if existing.debits.SameCurrency(amount) {
+			t = existing
+			break
+		}
+	}
+	if t == nil {
+		zero := money.New(fragment.NewAmount(0), amount.Currency)
+		t = &total{debits: zero, credits: zero}
+		totals = append(totals, t)
+	}
+	if debit {
+		t.debits = money.New(t.debits.Amount.Add(amount.Amount), amount.Currency)
+	} else {
+		t.credits = money.New(t.credits.Amount.Add(amount.Amount), amount.Currency)
+	}
+	return totals
+}
@@ -0,0 +1,108 @@
+package ledger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fragment-dev/fragment-go/money"
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+var types = AccountTypes{
+	"assets-root":   queries.LedgerAccountTypesAsset,
+	"liabilities":   queries.LedgerAccountTypesLiability,
+	"income-root":   queries.LedgerAccountTypesIncome,
+	"expense-root":  queries.LedgerAccountTypesExpense,
+	"liabilities/x": queries.LedgerAccountTypesAsset,
+}
+
+func mustParse(t *testing.T, s string) money.Money {
+	m, err := money.Parse(s)
+	if err != nil {
+		t.Fatalf("Got error parsing %s: %s", s, err)
+	}
+	return m
+}
+
+func TestLookup(t *testing.T) {
+	for path, expected := range map[string]queries.LedgerAccountTypes{
+		"assets-root":                       queries.LedgerAccountTypesAsset,
+		"liabilities/user:user-1/available": queries.LedgerAccountTypesLiability,
+		"liabilities/x/y":                   queries.LedgerAccountTypesAsset,
+	} {
+		if got, ok := types.Lookup(path); !ok || got != expected {
+			t.Errorf("Expected %s to be %s, got %s", path, expected, got)
+		}
+	}
+	if _, ok := types.Lookup("equity"); ok {
+		t.Errorf("Expected equity to have no type")
+	}
+}
+
+func TestEntryBuilder(t *testing.T) {
+	b := NewEntryBuilder("ledger-ik", "user_funds_account", types).
+		Debit("assets-root/bank", mustParse(t, "10.00 USD")).
+		Credit("liabilities/user:user-1/available", mustParse(t, "9.50 USD"), WithKey("funds")).
+		Credit("income-root/fees", mustParse(t, "0.50 USD")).
+		Debit("expense-root/fx", mustParse(t, "1 JPY")).
+		Credit("assets-root/bank", mustParse(t, "1 JPY"), WithDescription("FX")).
+		Tag("user", "user-1").
+		Group("batch", "batch-1")
+
+	lines, err := b.Lines()
+	if err != nil {
+		t.Fatalf("Got error from Lines: %s", err)
+	}
+	expected := []string{"1000", "950", "50", "1", "-1"}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d", len(expected), len(lines))
+	}
+	for i, line := range lines {
+		if line.Amount.String() != expected[i] {
+			t.Errorf("Expected line %d to have amount %s, got %s", i, expected[i], line.Amount)
+		}
+		if *line.Account.Ledger.Ik != "ledger-ik" {
+			t.Errorf("Expected line %d to match the ledger, got %+v", i, line.Account.Ledger)
+		}
+	}
+	if *lines[1].Key != "funds" || *lines[4].Description != "FX" || lines[3].Currency.Code != queries.CurrencyCodeJpy {
+		t.Errorf("Expected line options and currencies to be set, got %+v", lines)
+	}
+	if len(b.Tags()) != 1 || len(b.Groups()) != 1 {
+		t.Errorf("Expected a tag and a group, got %+v and %+v", b.Tags(), b.Groups())
+	}
+}
+
+func TestEntryBuilderUnbalanced(t *testing.T) {
+	b := NewEntryBuilder("ledger-ik", "user_funds_account", types).
+		Debit("assets-root/bank", mustParse(t, "10.00 USD")).
+		Credit("liabilities/user:user-1/available", mustParse(t, "7.50 USD")).
+		Debit("assets-root/bank", mustParse(t, "5.00 EUR")).
+		Credit("liabilities/user:user-1/available", mustParse(t, "5.00 EUR"))
+
+	err := b.Check()
+	if !errors.Is(err, ErrUnbalanced) {
+		t.Fatalf("Expected ErrUnbalanced, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "debits total 10.00 USD, credits total 7.50 USD") || strings.Contains(err.Error(), "EUR") {
+		t.Errorf("Expected the error to name the USD imbalance, got %s", err)
+	}
+	if _, err := b.Add(nil, "some-ik", nil); !errors.Is(err, ErrUnbalanced) {
+		t.Errorf("Expected Add to fail before making a request, got %v", err)
+	}
+}
+
+func TestEntryBuilderInvalidLines(t *testing.T) {
+	err := NewEntryBuilder("ledger-ik", "user_funds_account", types).
+		Debit("equity/owner", mustParse(t, "1.00 USD")).
+		Credit("liabilities/user:user-1/available", mustParse(t, "-1.00 USD")).
+		Check()
+	if err == nil || !strings.Contains(err.Error(), "Type of Ledger Account equity/owner is unknown") || !strings.Contains(err.Error(), "must be positive") {
+		t.Errorf("Expected errors for the unknown account and negative amount, got %v", err)
+	}
+
+	if err := NewEntryBuilder("ledger-ik", "user_funds_account", types).Check(); err == nil {
+		t.Errorf("Expected an error for an entry without lines")
+	}
+}
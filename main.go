@@ -73,15 +73,15 @@ func main() {
 		Operations:   args.Inputs,
 		Bindings: map[string]*generate.TypeBinding{
 			"AlphaNumericString":  {Type: "string"},
-			"Date":                {Type: "string"},
-			"DateTime":            {Type: "string"},
-			"Int64":               {Type: "string"},
-			"Int96":               {Type: "string"},
+			"Date":                {Type: "github.com/fragment-dev/fragment-go/fragment.Date"},
+			"DateTime":            {Type: "github.com/fragment-dev/fragment-go/fragment.DateTime"},
+			"Int64":               {Type: "github.com/fragment-dev/fragment-go/fragment.Amount"},
+			"Int96":               {Type: "github.com/fragment-dev/fragment-go/fragment.Amount"},
 			"JSON":                {Type: "encoding/json.RawMessage"},
 			"JSONObject":          {Type: "encoding/json.RawMessage"},
-			"LastMoment":          {Type: "string"},
+			"LastMoment":          {Type: "github.com/fragment-dev/fragment-go/fragment.LastMoment"},
 			"ParameterizedString": {Type: "string"},
-			"Period":              {Type: "string"},
+			"Period":              {Type: "github.com/fragment-dev/fragment-go/fragment.Period"},
 			"SafeString":          {Type: "string"},
 			"UTCOffset":           {Type: "string"},
 		},
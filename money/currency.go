@@ -0,0 +1,221 @@
+package money
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+// exponents maps each currency to the power of 10 by which its minor unit
+// divides its major unit, following ISO 4217 for fiat currencies and the
+// smallest on-chain unit for cryptocurrencies.
+var exponents = map[queries.CurrencyCode]int{
+	queries.CurrencyCodeAave:    18,
+	queries.CurrencyCodeAda:     6,
+	queries.CurrencyCodeAed:     2,
+	queries.CurrencyCodeAfn:     2,
+	queries.CurrencyCodeAll:     2,
+	queries.CurrencyCodeAmd:     2,
+	queries.CurrencyCodeAng:     2,
+	queries.CurrencyCodeAoa:     2,
+	queries.CurrencyCodeArs:     2,
+	queries.CurrencyCodeAud:     2,
+	queries.CurrencyCodeAwg:     2,
+	queries.CurrencyCodeAzn:     2,
+	queries.CurrencyCodeBam:     2,
+	queries.CurrencyCodeBbd:     2,
+	queries.CurrencyCodeBch:     8,
+	queries.CurrencyCodeBdt:     2,
+	queries.CurrencyCodeBgn:     2,
+	queries.CurrencyCodeBhd:     3,
+	queries.CurrencyCodeBif:     0,
+	queries.CurrencyCodeBmd:     2,
+	queries.CurrencyCodeBnd:     2,
+	queries.CurrencyCodeBob:     2,
+	queries.CurrencyCodeBrl:     2,
+	queries.CurrencyCodeBsd:     2,
+	queries.CurrencyCodeBtc:     8,
+	queries.CurrencyCodeBtn:     2,
+	queries.CurrencyCodeBwp:     2,
+	queries.CurrencyCodeByr:     0,
+	queries.CurrencyCodeBzd:     2,
+	queries.CurrencyCodeCad:     2,
+	queries.CurrencyCodeCdf:     2,
+	queries.CurrencyCodeChf:     2,
+	queries.CurrencyCodeClp:     0,
+	queries.CurrencyCodeCny:     2,
+	queries.CurrencyCodeCop:     2,
+	queries.CurrencyCodeCrc:     2,
+	queries.CurrencyCodeCuc:     2,
+	queries.CurrencyCodeCup:     2,
+	queries.CurrencyCodeCve:     2,
+	queries.CurrencyCodeCzk:     2,
+	queries.CurrencyCodeDai:     18,
+	queries.CurrencyCodeDjf:     0,
+	queries.CurrencyCodeDkk:     2,
+	queries.CurrencyCodeDop:     2,
+	queries.CurrencyCodeDzd:     2,
+	queries.CurrencyCodeEgp:     2,
+	queries.CurrencyCodeErn:     2,
+	queries.CurrencyCodeEtb:     2,
+	queries.CurrencyCodeEth:     18,
+	queries.CurrencyCodeEur:     2,
+	queries.CurrencyCodeFjd:     2,
+	queries.CurrencyCodeFkp:     2,
+	queries.CurrencyCodeGbp:     2,
+	queries.CurrencyCodeGel:     2,
+	queries.CurrencyCodeGgp:     2,
+	queries.CurrencyCodeGhs:     2,
+	queries.CurrencyCodeGip:     2,
+	queries.CurrencyCodeGmd:     2,
+	queries.CurrencyCodeGnf:     0,
+	queries.CurrencyCodeGtq:     2,
+	queries.CurrencyCodeGyd:     2,
+	queries.CurrencyCodeHkd:     2,
+	queries.CurrencyCodeHnl:     2,
+	queries.CurrencyCodeHrk:     2,
+	queries.CurrencyCodeHtg:     2,
+	queries.CurrencyCodeHuf:     2,
+	queries.CurrencyCodeIdr:     2,
+	queries.CurrencyCodeIls:     2,
+	queries.CurrencyCodeImp:     2,
+	queries.CurrencyCodeInr:     2,
+	queries.CurrencyCodeIqd:     3,
+	queries.CurrencyCodeIrr:     2,
+	queries.CurrencyCodeIsk:     0,
+	queries.CurrencyCodeJmd:     2,
+	queries.CurrencyCodeJod:     3,
+	queries.CurrencyCodeJpy:     0,
+	queries.CurrencyCodeKes:     2,
+	queries.CurrencyCodeKgs:     2,
+	queries.CurrencyCodeKhr:     2,
+	queries.CurrencyCodeKmf:     0,
+	queries.CurrencyCodeKpw:     2,
+	queries.CurrencyCodeKrw:     0,
+	queries.CurrencyCodeKwd:     3,
+	queries.CurrencyCodeKyd:     2,
+	queries.CurrencyCodeKzt:     2,
+	queries.CurrencyCodeLak:     2,
+	queries.CurrencyCodeLbp:     2,
+	queries.CurrencyCodeLink:    18,
+	queries.CurrencyCodeLkr:     2,
+	queries.CurrencyCodeLogical: 0,
+	queries.CurrencyCodeLrd:     2,
+	queries.CurrencyCodeLsl:     2,
+	queries.CurrencyCodeLtc:     8,
+	queries.CurrencyCodeLyd:     3,
+	queries.CurrencyCodeMad:     2,
+	queries.CurrencyCodeMatic:   18,
+	queries.CurrencyCodeMdl:     2,
+	queries.CurrencyCodeMga:     2,
+	queries.CurrencyCodeMkd:     2,
+	queries.CurrencyCodeMmk:     2,
+	queries.CurrencyCodeMnt:     2,
+	queries.CurrencyCodeMop:     2,
+	queries.CurrencyCodeMur:     2,
+	queries.CurrencyCodeMvr:     2,
+	queries.CurrencyCodeMwk:     2,
+	queries.CurrencyCodeMxn:     2,
+	queries.CurrencyCodeMyr:     2,
+	queries.CurrencyCodeMzn:     2,
+	queries.CurrencyCodeNad:     2,
+	queries.CurrencyCodeNgn:     2,
+	queries.CurrencyCodeNio:     2,
+	queries.CurrencyCodeNok:     2,
+	queries.CurrencyCodeNpr:     2,
+	queries.CurrencyCodeNzd:     2,
+	queries.CurrencyCodeOmr:     3,
+	queries.CurrencyCodePab:     2,
+	queries.CurrencyCodePen:     2,
+	queries.CurrencyCodePgk:     2,
+	queries.CurrencyCodePhp:     2,
+	queries.CurrencyCodePkr:     2,
+	queries.CurrencyCodePln:     2,
+	queries.CurrencyCodePts:     0,
+	queries.CurrencyCodePyg:     0,
+	queries.CurrencyCodeQar:     2,
+	queries.CurrencyCodeRon:     2,
+	queries.CurrencyCodeRsd:     2,
+	queries.CurrencyCodeRub:     2,
+	queries.CurrencyCodeRwf:     0,
+	queries.CurrencyCodeSar:     2,
+	queries.CurrencyCodeSbd:     2,
+	queries.CurrencyCodeScr:     2,
+	queries.CurrencyCodeSdg:     2,
+	queries.CurrencyCodeSek:     2,
+	queries.CurrencyCodeSgd:     2,
+	queries.CurrencyCodeShp:     2,
+	queries.CurrencyCodeSll:     2,
+	queries.CurrencyCodeSol:     9,
+	queries.CurrencyCodeSos:     2,
+	queries.CurrencyCodeSpl:     2,
+	queries.CurrencyCodeSrd:     2,
+	queries.CurrencyCodeStn:     2,
+	queries.CurrencyCodeSvc:     2,
+	queries.CurrencyCodeSyp:     2,
+	queries.CurrencyCodeSzl:     2,
+	queries.CurrencyCodeThb:     2,
+	queries.CurrencyCodeTjs:     2,
+	queries.CurrencyCodeTmt:     2,
+	queries.CurrencyCodeTnd:     3,
+	queries.CurrencyCodeTop:     2,
+	queries.CurrencyCodeTry:     2,
+	queries.CurrencyCodeTtd:     2,
+	queries.CurrencyCodeTvd:     2,
+	queries.CurrencyCodeTwd:     2,
+	queries.CurrencyCodeTzs:     2,
+	queries.CurrencyCodeUah:     2,
+	queries.CurrencyCodeUgx:     0,
+	queries.CurrencyCodeUni:     18,
+	queries.CurrencyCodeUsd:     2,
+	queries.CurrencyCodeUsdc:    6,
+	queries.CurrencyCodeUsdt:    6,
+	queries.CurrencyCodeUyu:     2,
+	queries.CurrencyCodeUzs:     2,
+	queries.CurrencyCodeVef:     2,
+	queries.CurrencyCodeVnd:     0,
+	queries.CurrencyCodeVuv:     0,
+	queries.CurrencyCodeWst:     2,
+	queries.CurrencyCodeXaf:     0,
+	queries.CurrencyCodeXcd:     2,
+	queries.CurrencyCodeXlm:     7,
+	queries.CurrencyCodeXof:     0,
+	queries.CurrencyCodeXpf:     0,
+	queries.CurrencyCodeYer:     2,
+	queries.CurrencyCodeZar:     2,
+	queries.CurrencyCodeZmw:     2,
+}
+
+var (
+	customMu        sync.RWMutex
+	customExponents = map[string]int{}
+)
+
+// RegisterCustomCurrency sets the exponent of the custom currency with id,
+// the CustomCurrencyId it was created with.
+func RegisterCustomCurrency(id string, exponent int) {
+	customMu.Lock()
+	defer customMu.Unlock()
+	customExponents[id] = exponent
+}
+
+// Exponent returns the power of 10 by which the minor unit of currency
+// divides its major unit, such as 2 for USD.
+func Exponent(currency queries.CurrencyMatchInput) (int, error) {
+	if currency.Code != queries.CurrencyCodeCustom {
+		if exponent, ok := exponents[currency.Code]; ok {
+			return exponent, nil
+		}
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency.Code)
+	}
+	if currency.CustomCurrencyId == nil {
+		return 0, fmt.Errorf("%w: a custom currency must have a CustomCurrencyId", ErrUnknownCurrency)
+	}
+	customMu.RLock()
+	defer customMu.RUnlock()
+	if exponent, ok := customExponents[*currency.CustomCurrencyId]; ok {
+		return exponent, nil
+	}
+	return 0, fmt.Errorf("%w: custom currency %s isn't registered", ErrUnknownCurrency, *currency.CustomCurrencyId)
+}
@@ -0,0 +1,165 @@
+// Package money pairs amounts with the currency they're denominated in, and
+// converts them between major units, such as "12.34 USD", and the minor units
+// used by the Fragment API.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fragment-dev/fragment-go/fragment"
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+var (
+	// ErrCurrencyMismatch is returned by arithmetic across different currencies.
+	ErrCurrencyMismatch = errors.New("Currencies don't match")
+	// ErrUnknownCurrency is returned for currencies whose exponent isn't known.
+	ErrUnknownCurrency = errors.New("Unknown currency")
+)
+
+// Money is an amount of minor units of a currency.
+type Money struct {
+	Amount   fragment.Amount
+	Currency queries.CurrencyMatchInput
+}
+
+// Code returns the CurrencyMatchInput of a currency code, such as
+// queries.CurrencyCodeUsd.
+func Code(code queries.CurrencyCode) queries.CurrencyMatchInput {
+	return queries.CurrencyMatchInput{Code: code}
+}
+
+// Custom returns the CurrencyMatchInput of the custom currency with id.
+func Custom(id string) queries.CurrencyMatchInput {
+	return queries.CurrencyMatchInput{Code: queries.CurrencyCodeCustom, CustomCurrencyId: &id}
+}
+
+// New returns Money of amount minor units of currency.
+func New(amount fragment.Amount, currency queries.CurrencyMatchInput) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse parses an amount in major units followed by a currency code or a
+// registered custom currency ID, such as "12.34 USD", into minor units.
+func Parse(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Money{}, fmt.Errorf("Invalid money %q: expected an amount and a currency", s)
+	}
+	currency := Code(queries.CurrencyCode(strings.ToUpper(fields[1])))
+	if _, ok := exponents[currency.Code]; !ok {
+		currency = Custom(fields[1])
+	}
+	return ParseMajor(fields[0], currency)
+}
+
+// ParseMajor parses an amount of currency in major units, such as "12.34",
+// into minor units. It returns an error if the amount has more decimal places
+// than the currency's exponent.
+func ParseMajor(amount string, currency queries.CurrencyMatchInput) (Money, error) {
+	exponent, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("Invalid amount %q: %s has %d decimal places", amount, currencyName(currency), exponent)
+	}
+	if strings.ContainsAny(fraction, "+-") || strings.TrimLeft(whole, "+-") == "" && fraction == "" {
+		return Money{}, fmt.Errorf("Invalid amount %q", amount)
+	}
+	minor, err := fragment.ParseAmount(whole + fraction + strings.Repeat("0", exponent-len(fraction)))
+	if err != nil {
+		return Money{}, fmt.Errorf("Invalid amount %q", amount)
+	}
+	return New(minor, currency), nil
+}
+
+// Major returns m in major units, such as "12.34".
+func (m Money) Major() (string, error) {
+	exponent, err := Exponent(m.Currency)
+	if err != nil {
+		return "", err
+	}
+	return m.Amount.Format(exponent), nil
+}
+
+// String returns m in major units followed by its currency, such as
+// "12.34 USD", or in minor units if its currency's exponent isn't known.
+func (m Money) String() string {
+	major, err := m.Major()
+	if err != nil {
+		major = m.Amount.String()
+	}
+	return major + " " + currencyName(m.Currency)
+}
+
+// SameCurrency reports whether m and o are in the same currency.
+func (m Money) SameCurrency(o Money) bool {
+	if m.Currency.Code != o.Currency.Code {
+		return false
+	}
+	if m.Currency.Code != queries.CurrencyCodeCustom {
+		return true
+	}
+	a, b := m.Currency.CustomCurrencyId, o.Currency.CustomCurrencyId
+	return a != nil && b != nil && *a == *b
+}
+
+func (m Money) checkCurrency(o Money) error {
+	if !m.SameCurrency(o) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, currencyName(m.Currency), currencyName(o.Currency))
+	}
+	return nil
+}
+
+// Add returns m + o. It returns ErrCurrencyMismatch if they're in different
+// currencies.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return New(m.Amount.Add(o.Amount), m.Currency), nil
+}
+
+// Sub returns m - o. It returns ErrCurrencyMismatch if they're in different
+// currencies.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return New(m.Amount.Sub(o.Amount), m.Currency), nil
+}
+
+// Cmp compares m and o, returning -1 if m < o, 0 if m == o and +1 if m > o.
+// It returns ErrCurrencyMismatch if they're in different currencies.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return New(m.Amount.Neg(), m.Currency)
+}
+
+// Mul returns m * n.
+func (m Money) Mul(n int64) Money {
+	return New(m.Amount.Mul(n), m.Currency)
+}
+
+// IsZero reports whether m is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+func currencyName(currency queries.CurrencyMatchInput) string {
+	if currency.Code == queries.CurrencyCodeCustom && currency.CustomCurrencyId != nil {
+		return *currency.CustomCurrencyId
+	}
+	return string(currency.Code)
+}
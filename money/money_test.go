@@ -0,0 +1,89 @@
+package money
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fragment-dev/fragment-go/fragment"
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+func TestParse(t *testing.T) {
+	RegisterCustomCurrency("loyalty-points", 1)
+
+	for _, test := range []struct {
+		input    string
+		minor    string
+		currency queries.CurrencyMatchInput
+	}{
+		{"12.34 USD", "1234", Code(queries.CurrencyCodeUsd)},
+		{"-0.5 usd", "-50", Code(queries.CurrencyCodeUsd)},
+		{"1500 JPY", "1500", Code(queries.CurrencyCodeJpy)},
+		{"1.234 KWD", "1234", Code(queries.CurrencyCodeKwd)},
+		{"0.00000001 BTC", "1", Code(queries.CurrencyCodeBtc)},
+		{"2.5 loyalty-points", "25", Custom("loyalty-points")},
+	} {
+		m, err := Parse(test.input)
+		if err != nil {
+			t.Errorf("Got error parsing %s: %s", test.input, err)
+			continue
+		}
+		if m.Amount.String() != test.minor || !m.SameCurrency(New(fragment.Amount{}, test.currency)) {
+			t.Errorf("Expected %s to be %s minor units of %+v, got %+v", test.input, test.minor, test.currency, m)
+		}
+	}
+
+	for _, input := range []string{"12.345 USD", "1.5 JPY", "12.34", "12.34 XYZ", "1.-5 USD", ". USD", "-. USD"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Expected an error parsing %s", input)
+		}
+	}
+	if _, err := ParseMajor("", Code(queries.CurrencyCodeUsd)); err == nil {
+		t.Errorf("Expected an error parsing an empty amount")
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, test := range []struct {
+		money    Money
+		expected string
+	}{
+		{New(fragment.NewAmount(1234), Code(queries.CurrencyCodeUsd)), "12.34 USD"},
+		{New(fragment.NewAmount(-5), Code(queries.CurrencyCodeEur)), "-0.05 EUR"},
+		{New(fragment.NewAmount(1500), Code(queries.CurrencyCodeJpy)), "1500 JPY"},
+		{New(fragment.NewAmount(7), Custom("unregistered")), "7 unregistered"},
+	} {
+		if got := test.money.String(); got != test.expected {
+			t.Errorf("Expected %s, got %s", test.expected, got)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	usd := func(minor int64) Money { return New(fragment.NewAmount(minor), Code(queries.CurrencyCodeUsd)) }
+
+	sum, err := usd(1234).Add(usd(66))
+	if err != nil || !sum.Amount.Equal(fragment.NewAmount(1300)) {
+		t.Errorf("Expected 13.00 USD, got %s (%v)", sum, err)
+	}
+	difference, err := usd(100).Sub(usd(250))
+	if err != nil || difference.String() != "-1.50 USD" {
+		t.Errorf("Expected -1.50 USD, got %s (%v)", difference, err)
+	}
+	if cmp, err := usd(1).Cmp(usd(2)); err != nil || cmp != -1 {
+		t.Errorf("Expected 0.01 USD < 0.02 USD, got %d (%v)", cmp, err)
+	}
+
+	eur := New(fragment.NewAmount(100), Code(queries.CurrencyCodeEur))
+	if _, err := usd(100).Add(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch adding USD and EUR, got %v", err)
+	}
+	points := New(fragment.NewAmount(100), Custom("points"))
+	miles := New(fragment.NewAmount(100), Custom("miles"))
+	if _, err := points.Sub(miles); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch across custom currencies, got %v", err)
+	}
+	if _, err := points.Cmp(points); err != nil {
+		t.Errorf("Got error comparing the same custom currency: %s", err)
+	}
+}
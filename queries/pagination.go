@@ -0,0 +1,197 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fragment-dev/fragment-go/auth"
+	"github.com/fragment-dev/fragment-go/client"
+	"github.com/fragment-dev/fragment-go/fragment"
+)
+
+// LedgerAccountsPages returns a fragment.FetchPage that fetches pages of the
+// Ledger Accounts of a Ledger with ListLedgerAccounts.
+func LedgerAccountsPages(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+) fragment.FetchPage[ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount] {
+	return func(reqCtx context.Context, req fragment.PageRequest) (*fragment.Page[ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount], error) {
+		pageCtx, cancel := client.WithRequestContext(ctx, reqCtx)
+		defer cancel()
+		resp, err := ListLedgerAccounts(pageCtx, ledgerIk, req.After, req.First, req.Before)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Ledger == nil || resp.Ledger.LedgerAccounts == nil {
+			return nil, fmt.Errorf("Ledger %s was not found", ledgerIk)
+		}
+		return &fragment.Page[ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount]{
+			Nodes:    resp.Ledger.LedgerAccounts.Nodes,
+			PageInfo: fragment.PageInfo(resp.Ledger.LedgerAccounts.PageInfo),
+		}, nil
+	}
+}
+
+// AllLedgerAccounts returns an iterator over the Ledger Accounts of a Ledger,
+// fetching pages with ListLedgerAccounts as they're needed.
+func AllLedgerAccounts(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+	opts ...fragment.PageOption,
+) *fragment.Iterator[ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount] {
+	return fragment.NewIterator(ctx, LedgerAccountsPages(ctx, ledgerIk), opts...)
+}
+
+// LedgerEntriesPages returns a fragment.FetchPage that fetches pages of the
+// Ledger Entries of a Ledger that match filter with ListLedgerEntries.
+func LedgerEntriesPages(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+	filter *LedgerEntriesFilterSet,
+) fragment.FetchPage[ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry] {
+	return func(reqCtx context.Context, req fragment.PageRequest) (*fragment.Page[ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry], error) {
+		pageCtx, cancel := client.WithRequestContext(ctx, reqCtx)
+		defer cancel()
+		resp, err := ListLedgerEntries(pageCtx, ledgerIk, req.After, req.First, req.Before, filter)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Ledger == nil || resp.Ledger.LedgerEntries == nil {
+			return nil, fmt.Errorf("Ledger %s was not found", ledgerIk)
+		}
+		return &fragment.Page[ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry]{
+			Nodes:    resp.Ledger.LedgerEntries.Nodes,
+			PageInfo: fragment.PageInfo(resp.Ledger.LedgerEntries.PageInfo),
+		}, nil
+	}
+}
+
+// AllLedgerEntries returns an iterator over the Ledger Entries of a Ledger
+// that match filter, fetching pages with ListLedgerEntries as they're needed.
+func AllLedgerEntries(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+	filter *LedgerEntriesFilterSet,
+	opts ...fragment.PageOption,
+) *fragment.Iterator[ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry] {
+	return fragment.NewIterator(ctx, LedgerEntriesPages(ctx, ledgerIk, filter), opts...)
+}
+
+// LedgerAccountLinesPages returns a fragment.FetchPage that fetches pages of
+// the Ledger Lines of a Ledger Account that match filter with
+// GetLedgerAccountLines.
+func LedgerAccountLinesPages(
+	ctx auth.AuthenticatedContext,
+	path string,
+	ledgerIk string,
+	filter *LedgerLinesFilterSet,
+) fragment.FetchPage[GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine] {
+	return func(reqCtx context.Context, req fragment.PageRequest) (*fragment.Page[GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine], error) {
+		pageCtx, cancel := client.WithRequestContext(ctx, reqCtx)
+		defer cancel()
+		resp, err := GetLedgerAccountLines(pageCtx, path, ledgerIk, req.After, req.First, req.Before, filter)
+		if err != nil {
+			return nil, err
+		}
+		if resp.LedgerAccount == nil {
+			return nil, fmt.Errorf("Ledger Account %s was not found in Ledger %s", path, ledgerIk)
+		}
+		return &fragment.Page[GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine]{
+			Nodes:    resp.LedgerAccount.Lines.Nodes,
+			PageInfo: fragment.PageInfo(resp.LedgerAccount.Lines.PageInfo),
+		}, nil
+	}
+}
+
+// AllLedgerAccountLines returns an iterator over the Ledger Lines of a Ledger
+// Account that match filter, fetching pages with GetLedgerAccountLines as
+// they're needed.
+func AllLedgerAccountLines(
+	ctx auth.AuthenticatedContext,
+	path string,
+	ledgerIk string,
+	filter *LedgerLinesFilterSet,
+	opts ...fragment.PageOption,
+) *fragment.Iterator[GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine] {
+	return fragment.NewIterator(ctx, LedgerAccountLinesPages(ctx, path, ledgerIk, filter), opts...)
+}
+
+// LedgerAccountBalancesPages returns a fragment.FetchPage that fetches pages
+// of the Ledger Accounts of a Ledger with their balances with
+// ListLedgerAccountBalances.
+func LedgerAccountBalancesPages(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+	balanceCurrency *CurrencyMatchInput,
+	balanceAt *fragment.LastMoment,
+	ownBalanceConsistencyMode *ReadBalanceConsistencyMode,
+) fragment.FetchPage[ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount] {
+	return func(reqCtx context.Context, req fragment.PageRequest) (*fragment.Page[ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount], error) {
+		pageCtx, cancel := client.WithRequestContext(ctx, reqCtx)
+		defer cancel()
+		resp, err := ListLedgerAccountBalances(pageCtx, ledgerIk, req.After, req.First, req.Before, balanceCurrency, balanceAt, ownBalanceConsistencyMode)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Ledger == nil || resp.Ledger.LedgerAccounts == nil {
+			return nil, fmt.Errorf("Ledger %s was not found", ledgerIk)
+		}
+		return &fragment.Page[ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount]{
+			Nodes:    resp.Ledger.LedgerAccounts.Nodes,
+			PageInfo: fragment.PageInfo(resp.Ledger.LedgerAccounts.PageInfo),
+		}, nil
+	}
+}
+
+// AllLedgerAccountBalances returns an iterator over the Ledger Accounts of a
+// Ledger with their balances, fetching pages with ListLedgerAccountBalances
+// as they're needed.
+func AllLedgerAccountBalances(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+	balanceCurrency *CurrencyMatchInput,
+	balanceAt *fragment.LastMoment,
+	ownBalanceConsistencyMode *ReadBalanceConsistencyMode,
+	opts ...fragment.PageOption,
+) *fragment.Iterator[ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount] {
+	return fragment.NewIterator(ctx, LedgerAccountBalancesPages(ctx, ledgerIk, balanceCurrency, balanceAt, ownBalanceConsistencyMode), opts...)
+}
+
+// MultiCurrencyLedgerAccountBalancesPages returns a fragment.FetchPage that
+// fetches pages of the Ledger Accounts of a Ledger with their balances in
+// every currency with ListMultiCurrencyLedgerAccountBalances.
+func MultiCurrencyLedgerAccountBalancesPages(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+	balanceAt *fragment.LastMoment,
+	ownBalancesConsistencyMode *ReadBalanceConsistencyMode,
+) fragment.FetchPage[ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount] {
+	return func(reqCtx context.Context, req fragment.PageRequest) (*fragment.Page[ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount], error) {
+		pageCtx, cancel := client.WithRequestContext(ctx, reqCtx)
+		defer cancel()
+		resp, err := ListMultiCurrencyLedgerAccountBalances(pageCtx, ledgerIk, req.After, req.First, req.Before, balanceAt, ownBalancesConsistencyMode)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Ledger == nil || resp.Ledger.LedgerAccounts == nil {
+			return nil, fmt.Errorf("Ledger %s was not found", ledgerIk)
+		}
+		return &fragment.Page[ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount]{
+			Nodes:    resp.Ledger.LedgerAccounts.Nodes,
+			PageInfo: fragment.PageInfo(resp.Ledger.LedgerAccounts.PageInfo),
+		}, nil
+	}
+}
+
+// AllMultiCurrencyLedgerAccountBalances returns an iterator over the Ledger
+// Accounts of a Ledger with their balances in every currency, fetching pages
+// with ListMultiCurrencyLedgerAccountBalances as they're needed.
+func AllMultiCurrencyLedgerAccountBalances(
+	ctx auth.AuthenticatedContext,
+	ledgerIk string,
+	balanceAt *fragment.LastMoment,
+	ownBalancesConsistencyMode *ReadBalanceConsistencyMode,
+	opts ...fragment.PageOption,
+) *fragment.Iterator[ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount] {
+	return fragment.NewIterator(ctx, MultiCurrencyLedgerAccountBalancesPages(ctx, ledgerIk, balanceAt, ownBalancesConsistencyMode), opts...)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Khan/genqlient/graphql"
 	"github.com/fragment-dev/fragment-go/auth"
 	"github.com/fragment-dev/fragment-go/client"
+	"github.com/fragment-dev/fragment-go/fragment"
 )
 
 // Check that context_type from genqlient.yaml implements context.Context.
@@ -138,9 +139,9 @@ type AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry struct {
 	// The idempotency key used to post this ledger entry
 	Ik string `json:"ik"`
 	// ISO-8601 timestamp this LedgerEntry posted to its Ledger.
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 	// ISO-8601 timestamp this LedgerEntry was created in Fragment.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 }
 
 // GetType returns AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry.Type, and is useful for accessing the field via an interface.
@@ -159,12 +160,12 @@ func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetIk
 }
 
 // GetPosted returns AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry.Posted, and is useful for accessing the field via an interface.
-func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetPosted() string {
+func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetPosted() fragment.DateTime {
 	return v.Posted
 }
 
 // GetCreated returns AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry.Created, and is useful for accessing the field via an interface.
-func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetCreated() string {
+func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -172,7 +173,7 @@ func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetCr
 type AddLedgerEntryAddLedgerEntryAddLedgerEntryResultLinesLedgerLine struct {
 	Id string `json:"id"`
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// LedgerAccount that contains this line
 	Account AddLedgerEntryAddLedgerEntryAddLedgerEntryResultLinesLedgerLineAccountLedgerAccount `json:"account"`
 }
@@ -181,7 +182,7 @@ type AddLedgerEntryAddLedgerEntryAddLedgerEntryResultLinesLedgerLine struct {
 func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultLinesLedgerLine) GetId() string { return v.Id }
 
 // GetAmount returns AddLedgerEntryAddLedgerEntryAddLedgerEntryResultLinesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultLinesLedgerLine) GetAmount() string {
+func (v *AddLedgerEntryAddLedgerEntryAddLedgerEntryResultLinesLedgerLine) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -449,9 +450,9 @@ type AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry str
 	// The idempotency key used to post this ledger entry
 	Ik string `json:"ik"`
 	// ISO-8601 timestamp this LedgerEntry posted to its Ledger.
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 	// ISO-8601 timestamp this LedgerEntry was created in Fragment.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 }
 
 // GetType returns AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry.Type, and is useful for accessing the field via an interface.
@@ -470,12 +471,12 @@ func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry
 }
 
 // GetPosted returns AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry.Posted, and is useful for accessing the field via an interface.
-func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetPosted() string {
+func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetPosted() fragment.DateTime {
 	return v.Posted
 }
 
 // GetCreated returns AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry.Created, and is useful for accessing the field via an interface.
-func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetCreated() string {
+func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -483,7 +484,7 @@ func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultEntryLedgerEntry
 type AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultLinesLedgerLine struct {
 	Id string `json:"id"`
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// LedgerAccount that contains this line
 	Account AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultLinesLedgerLineAccountLedgerAccount `json:"account"`
 }
@@ -494,7 +495,7 @@ func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultLinesLedgerLine)
 }
 
 // GetAmount returns AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultLinesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultLinesLedgerLine) GetAmount() string {
+func (v *AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResultLinesLedgerLine) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -906,7 +907,7 @@ type CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLink interface {
 	// The GraphQL interface field's documentation follows.
 	//
 	// ISO-8601 timestamp when the Link was created.
-	GetCreated() string
+	GetCreated() fragment.DateTime
 }
 
 func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkCustomLink) implementsGraphQLInterfaceCreateCustomLinkCreateCustomLinkCreateCustomLinkResultLink() {
@@ -1005,7 +1006,7 @@ type CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkCustomLink struct
 	// Name of the Link as it appears in the Dashboard.
 	Name string `json:"name"`
 	// ISO-8601 timestamp when the Link was created.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 }
 
 // GetTypename returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkCustomLink.Typename, and is useful for accessing the field via an interface.
@@ -1024,7 +1025,7 @@ func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkCustomLink) G
 }
 
 // GetCreated returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkCustomLink.Created, and is useful for accessing the field via an interface.
-func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkCustomLink) GetCreated() string {
+func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkCustomLink) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -1036,7 +1037,7 @@ type CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkIncreaseLink stru
 	// Name of the Link as it appears in the Dashboard.
 	Name string `json:"name"`
 	// ISO-8601 timestamp when the Link was created.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 }
 
 // GetTypename returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkIncreaseLink.Typename, and is useful for accessing the field via an interface.
@@ -1055,7 +1056,7 @@ func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkIncreaseLink)
 }
 
 // GetCreated returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkIncreaseLink.Created, and is useful for accessing the field via an interface.
-func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkIncreaseLink) GetCreated() string {
+func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkIncreaseLink) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -1067,7 +1068,7 @@ type CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkStripeLink struct
 	// Name of the Link as it appears in the Dashboard.
 	Name string `json:"name"`
 	// ISO-8601 timestamp when the Link was created.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 }
 
 // GetTypename returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkStripeLink.Typename, and is useful for accessing the field via an interface.
@@ -1086,7 +1087,7 @@ func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkStripeLink) G
 }
 
 // GetCreated returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkStripeLink.Created, and is useful for accessing the field via an interface.
-func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkStripeLink) GetCreated() string {
+func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkStripeLink) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -1098,7 +1099,7 @@ type CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkUnitLink struct {
 	// Name of the Link as it appears in the Dashboard.
 	Name string `json:"name"`
 	// ISO-8601 timestamp when the Link was created.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 }
 
 // GetTypename returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkUnitLink.Typename, and is useful for accessing the field via an interface.
@@ -1117,7 +1118,7 @@ func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkUnitLink) Get
 }
 
 // GetCreated returns CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkUnitLink.Created, and is useful for accessing the field via an interface.
-func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkUnitLink) GetCreated() string {
+func (v *CreateCustomLinkCreateCustomLinkCreateCustomLinkResultLinkUnitLink) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -1353,8 +1354,8 @@ type CreateLedgerCreateLedgerCreateLedgerResultLedger struct {
 	// The IK passed into the [createLedger](/api-reference#mutations-createledger) mutation. This is treated as a unique identifier for this Ledger.
 	Ik string `json:"ik"`
 	// The name of the Ledger. Can be updated with the [updateLedger](/api-reference#mutations-updateledger) mutation.
-	Name    string `json:"name"`
-	Created string `json:"created"`
+	Name    string            `json:"name"`
+	Created fragment.DateTime `json:"created"`
 	// Schema key associated with this Ledger.
 	Schema *CreateLedgerCreateLedgerCreateLedgerResultLedgerSchema `json:"schema"`
 }
@@ -1369,7 +1370,9 @@ func (v *CreateLedgerCreateLedgerCreateLedgerResultLedger) GetIk() string { retu
 func (v *CreateLedgerCreateLedgerCreateLedgerResultLedger) GetName() string { return v.Name }
 
 // GetCreated returns CreateLedgerCreateLedgerCreateLedgerResultLedger.Created, and is useful for accessing the field via an interface.
-func (v *CreateLedgerCreateLedgerCreateLedgerResultLedger) GetCreated() string { return v.Created }
+func (v *CreateLedgerCreateLedgerCreateLedgerResultLedger) GetCreated() fragment.DateTime {
+	return v.Created
+}
 
 // GetSchema returns CreateLedgerCreateLedgerCreateLedgerResultLedger.Schema, and is useful for accessing the field via an interface.
 func (v *CreateLedgerCreateLedgerCreateLedgerResultLedger) GetSchema() *CreateLedgerCreateLedgerCreateLedgerResultLedgerSchema {
@@ -1728,20 +1731,20 @@ func (v *CustomAccountInput) GetName() string { return v.Name }
 
 type CustomTxInput struct {
 	Account ExternalAccountMatchInput `json:"account"`
-	Amount  string                    `json:"amount"`
+	Amount  fragment.Amount           `json:"amount"`
 	// The currency of this tx. Should be set for multi-currency accounts.
 	Currency    *CurrencyMatchInput `json:"currency"`
 	Description string              `json:"description"`
 	// The ID of this tx at the external system. This is used as the idempotency key, within the scope of its Custom Account.
-	ExternalId string `json:"externalId"`
-	Posted     string `json:"posted"`
+	ExternalId string            `json:"externalId"`
+	Posted     fragment.DateTime `json:"posted"`
 }
 
 // GetAccount returns CustomTxInput.Account, and is useful for accessing the field via an interface.
 func (v *CustomTxInput) GetAccount() ExternalAccountMatchInput { return v.Account }
 
 // GetAmount returns CustomTxInput.Amount, and is useful for accessing the field via an interface.
-func (v *CustomTxInput) GetAmount() string { return v.Amount }
+func (v *CustomTxInput) GetAmount() fragment.Amount { return v.Amount }
 
 // GetCurrency returns CustomTxInput.Currency, and is useful for accessing the field via an interface.
 func (v *CustomTxInput) GetCurrency() *CurrencyMatchInput { return v.Currency }
@@ -1753,32 +1756,32 @@ func (v *CustomTxInput) GetDescription() string { return v.Description }
 func (v *CustomTxInput) GetExternalId() string { return v.ExternalId }
 
 // GetPosted returns CustomTxInput.Posted, and is useful for accessing the field via an interface.
-func (v *CustomTxInput) GetPosted() string { return v.Posted }
+func (v *CustomTxInput) GetPosted() fragment.DateTime { return v.Posted }
 
 type DateFilter struct {
-	EqualTo *string  `json:"equalTo"`
-	In      []string `json:"in"`
+	EqualTo *fragment.Date  `json:"equalTo"`
+	In      []fragment.Date `json:"in"`
 }
 
 // GetEqualTo returns DateFilter.EqualTo, and is useful for accessing the field via an interface.
-func (v *DateFilter) GetEqualTo() *string { return v.EqualTo }
+func (v *DateFilter) GetEqualTo() *fragment.Date { return v.EqualTo }
 
 // GetIn returns DateFilter.In, and is useful for accessing the field via an interface.
-func (v *DateFilter) GetIn() []string { return v.In }
+func (v *DateFilter) GetIn() []fragment.Date { return v.In }
 
 // Filters a timestamp field between two moments in time
 type DateTimeFilter struct {
 	// The timestamp value must be after this moment. Specified in ISO 8601 format e.g "1968-01-01T16:45:00Z"
-	After *string `json:"after"`
+	After *fragment.DateTime `json:"after"`
 	// The timestamp value must be before this moment. Specified in ISO 8601 format e.g "1968-01-01T16:45:00Z"
-	Before *string `json:"before"`
+	Before *fragment.DateTime `json:"before"`
 }
 
 // GetAfter returns DateTimeFilter.After, and is useful for accessing the field via an interface.
-func (v *DateTimeFilter) GetAfter() *string { return v.After }
+func (v *DateTimeFilter) GetAfter() *fragment.DateTime { return v.After }
 
 // GetBefore returns DateTimeFilter.Before, and is useful for accessing the field via an interface.
-func (v *DateTimeFilter) GetBefore() *string { return v.Before }
+func (v *DateTimeFilter) GetBefore() *fragment.DateTime { return v.Before }
 
 // Specify an External Account by using `id`, or  `linkId` and `externalId`.
 type ExternalAccountMatchInput struct {
@@ -1810,7 +1813,7 @@ type GetLedgerAccountBalanceLedgerAccount struct {
 	// this will be composed of the IKs of an account and its ancestors.
 	Path string `json:"path"`
 	// Total of all lines in this ledger account, excluding all child ledger accounts
-	OwnBalance string `json:"ownBalance"`
+	OwnBalance fragment.Amount `json:"ownBalance"`
 }
 
 // GetId returns GetLedgerAccountBalanceLedgerAccount.Id, and is useful for accessing the field via an interface.
@@ -1820,7 +1823,7 @@ func (v *GetLedgerAccountBalanceLedgerAccount) GetId() string { return v.Id }
 func (v *GetLedgerAccountBalanceLedgerAccount) GetPath() string { return v.Path }
 
 // GetOwnBalance returns GetLedgerAccountBalanceLedgerAccount.OwnBalance, and is useful for accessing the field via an interface.
-func (v *GetLedgerAccountBalanceLedgerAccount) GetOwnBalance() string { return v.OwnBalance }
+func (v *GetLedgerAccountBalanceLedgerAccount) GetOwnBalance() fragment.Amount { return v.OwnBalance }
 
 // GetLedgerAccountBalanceResponse is returned by GetLedgerAccountBalance on success.
 type GetLedgerAccountBalanceResponse struct {
@@ -1883,11 +1886,11 @@ func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnection) GetPageIn
 type GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine struct {
 	Id string `json:"id"`
 	// ISO-8601 timestamp this LedgerLine posted to its LedgerAccount
-	Posted *string `json:"posted"`
+	Posted *fragment.DateTime `json:"posted"`
 	// ISO-8601 timestamp this LedgerLine was created in Fragment
-	Created *string `json:"created"`
+	Created *fragment.DateTime `json:"created"`
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// Description of this LedgerLine
 	Description *string `json:"description"`
 }
@@ -1898,17 +1901,17 @@ func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedger
 }
 
 // GetPosted returns GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine.Posted, and is useful for accessing the field via an interface.
-func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine) GetPosted() *string {
+func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine) GetPosted() *fragment.DateTime {
 	return v.Posted
 }
 
 // GetCreated returns GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine.Created, and is useful for accessing the field via an interface.
-func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine) GetCreated() *string {
+func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine) GetCreated() *fragment.DateTime {
 	return v.Created
 }
 
 // GetAmount returns GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() string {
+func (v *GetLedgerAccountLinesLedgerAccountLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -1966,9 +1969,9 @@ type GetLedgerEntryLedgerEntry struct {
 	// The idempotency key used to post this ledger entry
 	Ik string `json:"ik"`
 	// ISO-8601 timestamp this LedgerEntry posted to its Ledger.
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 	// ISO-8601 timestamp this LedgerEntry was created in Fragment.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 	// Description posted for this Ledger Entry.
 	Description *string `json:"description"`
 	// Lines posted in this Ledger Entry.
@@ -1982,10 +1985,10 @@ func (v *GetLedgerEntryLedgerEntry) GetId() string { return v.Id }
 func (v *GetLedgerEntryLedgerEntry) GetIk() string { return v.Ik }
 
 // GetPosted returns GetLedgerEntryLedgerEntry.Posted, and is useful for accessing the field via an interface.
-func (v *GetLedgerEntryLedgerEntry) GetPosted() string { return v.Posted }
+func (v *GetLedgerEntryLedgerEntry) GetPosted() fragment.DateTime { return v.Posted }
 
 // GetCreated returns GetLedgerEntryLedgerEntry.Created, and is useful for accessing the field via an interface.
-func (v *GetLedgerEntryLedgerEntry) GetCreated() string { return v.Created }
+func (v *GetLedgerEntryLedgerEntry) GetCreated() fragment.DateTime { return v.Created }
 
 // GetDescription returns GetLedgerEntryLedgerEntry.Description, and is useful for accessing the field via an interface.
 func (v *GetLedgerEntryLedgerEntry) GetDescription() *string { return v.Description }
@@ -2013,7 +2016,7 @@ func (v *GetLedgerEntryLedgerEntryLinesLedgerLinesConnection) GetNodes() []GetLe
 type GetLedgerEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine struct {
 	Id string `json:"id"`
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// LedgerAccount that contains this line
 	Account GetLedgerEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLineAccountLedgerAccount `json:"account"`
 }
@@ -2024,7 +2027,7 @@ func (v *GetLedgerEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine) Get
 }
 
 // GetAmount returns GetLedgerEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *GetLedgerEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() string {
+func (v *GetLedgerEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -2067,8 +2070,8 @@ type GetLedgerLedger struct {
 	// The IK passed into the [createLedger](/api-reference#mutations-createledger) mutation. This is treated as a unique identifier for this Ledger.
 	Ik string `json:"ik"`
 	// The name of the Ledger. Can be updated with the [updateLedger](/api-reference#mutations-updateledger) mutation.
-	Name    string `json:"name"`
-	Created string `json:"created"`
+	Name    string            `json:"name"`
+	Created fragment.DateTime `json:"created"`
 	// When aggregating balances, all transactions within a 24 hour period starting at midnight UTC plus this offset are included in each day.
 	BalanceUTCOffset string `json:"balanceUTCOffset"`
 }
@@ -2083,7 +2086,7 @@ func (v *GetLedgerLedger) GetIk() string { return v.Ik }
 func (v *GetLedgerLedger) GetName() string { return v.Name }
 
 // GetCreated returns GetLedgerLedger.Created, and is useful for accessing the field via an interface.
-func (v *GetLedgerLedger) GetCreated() string { return v.Created }
+func (v *GetLedgerLedger) GetCreated() fragment.DateTime { return v.Created }
 
 // GetBalanceUTCOffset returns GetLedgerLedger.BalanceUTCOffset, and is useful for accessing the field via an interface.
 func (v *GetLedgerLedger) GetBalanceUTCOffset() string { return v.BalanceUTCOffset }
@@ -2133,14 +2136,14 @@ func (v *GetSchemaSchema) GetVersion() GetSchemaSchemaVersion { return v.Version
 // A new SchemaVersion is created each time a Schema is stored.
 // It stores the Chart of Accounts and list of Ledger Entries as well as a history of its Ledger migrations.
 type GetSchemaSchemaVersion struct {
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 	// The version of the schema.
 	Version int             `json:"version"`
 	Json    json.RawMessage `json:"json"`
 }
 
 // GetCreated returns GetSchemaSchemaVersion.Created, and is useful for accessing the field via an interface.
-func (v *GetSchemaSchemaVersion) GetCreated() string { return v.Created }
+func (v *GetSchemaSchemaVersion) GetCreated() fragment.DateTime { return v.Created }
 
 // GetVersion returns GetSchemaSchemaVersion.Version, and is useful for accessing the field via an interface.
 func (v *GetSchemaSchemaVersion) GetVersion() int { return v.Version }
@@ -2325,7 +2328,7 @@ type LedgerLineInput struct {
 	// The LedgerAccount this line is being added to
 	Account LedgerAccountMatchInput `json:"account"`
 	// A positive amount increases the balance of its LedgerAccount, a negative amount reduces the balance of its LedgerAccount
-	Amount *string `json:"amount"`
+	Amount *fragment.Amount `json:"amount"`
 	// The currency the ledger line is in
 	Currency *CurrencyMatchInput `json:"currency"`
 	// If not specified the description from the parent LedgerEntryInput will be used
@@ -2340,7 +2343,7 @@ type LedgerLineInput struct {
 func (v *LedgerLineInput) GetAccount() LedgerAccountMatchInput { return v.Account }
 
 // GetAmount returns LedgerLineInput.Amount, and is useful for accessing the field via an interface.
-func (v *LedgerLineInput) GetAmount() *string { return v.Amount }
+func (v *LedgerLineInput) GetAmount() *fragment.Amount { return v.Amount }
 
 // GetCurrency returns LedgerLineInput.Currency, and is useful for accessing the field via an interface.
 func (v *LedgerLineInput) GetCurrency() *CurrencyMatchInput { return v.Currency }
@@ -2410,8 +2413,8 @@ type ListLedgerAccountBalancesLedger struct {
 	// The IK passed into the [createLedger](/api-reference#mutations-createledger) mutation. This is treated as a unique identifier for this Ledger.
 	Ik string `json:"ik"`
 	// The name of the Ledger. Can be updated with the [updateLedger](/api-reference#mutations-updateledger) mutation.
-	Name    string `json:"name"`
-	Created string `json:"created"`
+	Name    string            `json:"name"`
+	Created fragment.DateTime `json:"created"`
 	// Query LedgerAccounts in Ledger. Ledger Accounts are paginated and returned in reverse-chronological order by their created date.
 	LedgerAccounts *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnection `json:"ledgerAccounts"`
 }
@@ -2426,7 +2429,7 @@ func (v *ListLedgerAccountBalancesLedger) GetIk() string { return v.Ik }
 func (v *ListLedgerAccountBalancesLedger) GetName() string { return v.Name }
 
 // GetCreated returns ListLedgerAccountBalancesLedger.Created, and is useful for accessing the field via an interface.
-func (v *ListLedgerAccountBalancesLedger) GetCreated() string { return v.Created }
+func (v *ListLedgerAccountBalancesLedger) GetCreated() fragment.DateTime { return v.Created }
 
 // GetLedgerAccounts returns ListLedgerAccountBalancesLedger.LedgerAccounts, and is useful for accessing the field via an interface.
 func (v *ListLedgerAccountBalancesLedger) GetLedgerAccounts() *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnection {
@@ -2467,13 +2470,13 @@ type ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesL
 	// The name of your Ledger Account
 	Name    *string            `json:"name"`
 	Type    LedgerAccountTypes `json:"type"`
-	Created string             `json:"created"`
+	Created fragment.DateTime  `json:"created"`
 	// Total of all lines in this ledger account, excluding all child ledger accounts
-	OwnBalance string `json:"ownBalance"`
+	OwnBalance fragment.Amount `json:"ownBalance"`
 	// Total of all lines in child ledger accounts of the same currency as this ledger account
-	ChildBalance string `json:"childBalance"`
+	ChildBalance fragment.Amount `json:"childBalance"`
 	// Total of all lines in this ledger account and child ledger accounts of the same currency as this ledger account
-	Balance string `json:"balance"`
+	Balance fragment.Amount `json:"balance"`
 }
 
 // GetId returns ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.Id, and is useful for accessing the field via an interface.
@@ -2497,22 +2500,22 @@ func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNo
 }
 
 // GetCreated returns ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.Created, and is useful for accessing the field via an interface.
-func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetCreated() string {
+func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
 // GetOwnBalance returns ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.OwnBalance, and is useful for accessing the field via an interface.
-func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetOwnBalance() string {
+func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetOwnBalance() fragment.Amount {
 	return v.OwnBalance
 }
 
 // GetChildBalance returns ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.ChildBalance, and is useful for accessing the field via an interface.
-func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetChildBalance() string {
+func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetChildBalance() fragment.Amount {
 	return v.ChildBalance
 }
 
 // GetBalance returns ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.Balance, and is useful for accessing the field via an interface.
-func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetBalance() string {
+func (v *ListLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetBalance() fragment.Amount {
 	return v.Balance
 }
 
@@ -2567,8 +2570,8 @@ type ListLedgerAccountsLedger struct {
 	// The IK passed into the [createLedger](/api-reference#mutations-createledger) mutation. This is treated as a unique identifier for this Ledger.
 	Ik string `json:"ik"`
 	// The name of the Ledger. Can be updated with the [updateLedger](/api-reference#mutations-updateledger) mutation.
-	Name    string `json:"name"`
-	Created string `json:"created"`
+	Name    string            `json:"name"`
+	Created fragment.DateTime `json:"created"`
 	// Query LedgerAccounts in Ledger. Ledger Accounts are paginated and returned in reverse-chronological order by their created date.
 	LedgerAccounts *ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnection `json:"ledgerAccounts"`
 }
@@ -2583,7 +2586,7 @@ func (v *ListLedgerAccountsLedger) GetIk() string { return v.Ik }
 func (v *ListLedgerAccountsLedger) GetName() string { return v.Name }
 
 // GetCreated returns ListLedgerAccountsLedger.Created, and is useful for accessing the field via an interface.
-func (v *ListLedgerAccountsLedger) GetCreated() string { return v.Created }
+func (v *ListLedgerAccountsLedger) GetCreated() fragment.DateTime { return v.Created }
 
 // GetLedgerAccounts returns ListLedgerAccountsLedger.LedgerAccounts, and is useful for accessing the field via an interface.
 func (v *ListLedgerAccountsLedger) GetLedgerAccounts() *ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnection {
@@ -2624,7 +2627,7 @@ type ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAc
 	// The name of your Ledger Account
 	Name    *string            `json:"name"`
 	Type    LedgerAccountTypes `json:"type"`
-	Created string             `json:"created"`
+	Created fragment.DateTime  `json:"created"`
 }
 
 // GetId returns ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.Id, and is useful for accessing the field via an interface.
@@ -2648,7 +2651,7 @@ func (v *ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedg
 }
 
 // GetCreated returns ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.Created, and is useful for accessing the field via an interface.
-func (v *ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetCreated() string {
+func (v *ListLedgerAccountsLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -2734,7 +2737,7 @@ type ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry
 	// The type of the Ledger Entry.
 	Type *string `json:"type"`
 	// ISO-8601 timestamp this LedgerEntry posted to its Ledger.
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 	// Lines posted in this Ledger Entry.
 	Lines ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntryLinesLedgerLinesConnection `json:"lines"`
 }
@@ -2750,7 +2753,7 @@ func (v *ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerE
 }
 
 // GetPosted returns ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry.Posted, and is useful for accessing the field via an interface.
-func (v *ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry) GetPosted() string {
+func (v *ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntry) GetPosted() fragment.DateTime {
 	return v.Posted
 }
 
@@ -2776,13 +2779,13 @@ func (v *ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerE
 // ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine includes the requested fields of the GraphQL type LedgerLine.
 type ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine struct {
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// LedgerAccount that contains this line
 	Account ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntryLinesLedgerLinesConnectionNodesLedgerLineAccountLedgerAccount `json:"account"`
 }
 
 // GetAmount returns ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() string {
+func (v *ListLedgerEntriesLedgerLedgerEntriesLedgerEntriesConnectionNodesLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -2856,8 +2859,8 @@ type ListMultiCurrencyLedgerAccountBalancesLedger struct {
 	// The IK passed into the [createLedger](/api-reference#mutations-createledger) mutation. This is treated as a unique identifier for this Ledger.
 	Ik string `json:"ik"`
 	// The name of the Ledger. Can be updated with the [updateLedger](/api-reference#mutations-updateledger) mutation.
-	Name    string `json:"name"`
-	Created string `json:"created"`
+	Name    string            `json:"name"`
+	Created fragment.DateTime `json:"created"`
 	// Query LedgerAccounts in Ledger. Ledger Accounts are paginated and returned in reverse-chronological order by their created date.
 	LedgerAccounts *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnection `json:"ledgerAccounts"`
 }
@@ -2872,7 +2875,9 @@ func (v *ListMultiCurrencyLedgerAccountBalancesLedger) GetIk() string { return v
 func (v *ListMultiCurrencyLedgerAccountBalancesLedger) GetName() string { return v.Name }
 
 // GetCreated returns ListMultiCurrencyLedgerAccountBalancesLedger.Created, and is useful for accessing the field via an interface.
-func (v *ListMultiCurrencyLedgerAccountBalancesLedger) GetCreated() string { return v.Created }
+func (v *ListMultiCurrencyLedgerAccountBalancesLedger) GetCreated() fragment.DateTime {
+	return v.Created
+}
 
 // GetLedgerAccounts returns ListMultiCurrencyLedgerAccountBalancesLedger.LedgerAccounts, and is useful for accessing the field via an interface.
 func (v *ListMultiCurrencyLedgerAccountBalancesLedger) GetLedgerAccounts() *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnection {
@@ -2913,7 +2918,7 @@ type ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsCon
 	// The name of your Ledger Account
 	Name    *string            `json:"name"`
 	Type    LedgerAccountTypes `json:"type"`
-	Created string             `json:"created"`
+	Created fragment.DateTime  `json:"created"`
 	// Total of all lines across all currencies in this ledger account, excluding all child ledger accounts
 	OwnBalances ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountOwnBalancesCurrencyAmountConnection `json:"ownBalances"`
 	// Total of all lines in child ledger accounts of this ledger in all currencies
@@ -2943,7 +2948,7 @@ func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccount
 }
 
 // GetCreated returns ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount.Created, and is useful for accessing the field via an interface.
-func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetCreated() string {
+func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccount) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -2984,7 +2989,7 @@ type ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsCon
 	// The currency this amount is in
 	Currency ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountBalancesCurrencyAmountConnectionNodesCurrencyAmountCurrency `json:"currency"`
 	// Numerical integer value, serialized as a string
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 }
 
 // GetCurrency returns ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountBalancesCurrencyAmountConnectionNodesCurrencyAmount.Currency, and is useful for accessing the field via an interface.
@@ -2993,7 +2998,7 @@ func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccount
 }
 
 // GetAmount returns ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountBalancesCurrencyAmountConnectionNodesCurrencyAmount.Amount, and is useful for accessing the field via an interface.
-func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountBalancesCurrencyAmountConnectionNodesCurrencyAmount) GetAmount() string {
+func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountBalancesCurrencyAmountConnectionNodesCurrencyAmount) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -3037,7 +3042,7 @@ type ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsCon
 	// The currency this amount is in
 	Currency ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountChildBalancesCurrencyAmountConnectionNodesCurrencyAmountCurrency `json:"currency"`
 	// Numerical integer value, serialized as a string
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 }
 
 // GetCurrency returns ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountChildBalancesCurrencyAmountConnectionNodesCurrencyAmount.Currency, and is useful for accessing the field via an interface.
@@ -3046,7 +3051,7 @@ func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccount
 }
 
 // GetAmount returns ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountChildBalancesCurrencyAmountConnectionNodesCurrencyAmount.Amount, and is useful for accessing the field via an interface.
-func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountChildBalancesCurrencyAmountConnectionNodesCurrencyAmount) GetAmount() string {
+func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountChildBalancesCurrencyAmountConnectionNodesCurrencyAmount) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -3090,7 +3095,7 @@ type ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsCon
 	// The currency this amount is in
 	Currency ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountOwnBalancesCurrencyAmountConnectionNodesCurrencyAmountCurrency `json:"currency"`
 	// Numerical integer value, serialized as a string
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 }
 
 // GetCurrency returns ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountOwnBalancesCurrencyAmountConnectionNodesCurrencyAmount.Currency, and is useful for accessing the field via an interface.
@@ -3099,7 +3104,7 @@ func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccount
 }
 
 // GetAmount returns ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountOwnBalancesCurrencyAmountConnectionNodesCurrencyAmount.Amount, and is useful for accessing the field via an interface.
-func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountOwnBalancesCurrencyAmountConnectionNodesCurrencyAmount) GetAmount() string {
+func (v *ListMultiCurrencyLedgerAccountBalancesLedgerLedgerAccountsLedgerAccountsConnectionNodesLedgerAccountOwnBalancesCurrencyAmountConnectionNodesCurrencyAmount) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -3336,11 +3341,11 @@ type ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry struct {
 	// The idempotency key used to post this ledger entry
 	Ik string `json:"ik"`
 	// Date this LedgerEntry posted to its Ledger e.g. "2021-01-01".
-	Date string `json:"date"`
+	Date fragment.Date `json:"date"`
 	// ISO-8601 timestamp this LedgerEntry posted to its Ledger.
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 	// ISO-8601 timestamp this LedgerEntry was created in Fragment.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 	// Description posted for this Ledger Entry.
 	Description *string `json:"description"`
 }
@@ -3352,13 +3357,17 @@ func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetId() string
 func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetIk() string { return v.Ik }
 
 // GetDate returns ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry.Date, and is useful for accessing the field via an interface.
-func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetDate() string { return v.Date }
+func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetDate() fragment.Date {
+	return v.Date
+}
 
 // GetPosted returns ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry.Posted, and is useful for accessing the field via an interface.
-func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetPosted() string { return v.Posted }
+func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetPosted() fragment.DateTime {
+	return v.Posted
+}
 
 // GetCreated returns ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry.Created, and is useful for accessing the field via an interface.
-func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetCreated() string {
+func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -3371,7 +3380,7 @@ func (v *ReconcileTxReconcileTxReconcileTxResultEntryLedgerEntry) GetDescription
 type ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine struct {
 	Id string `json:"id"`
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// LedgerAccount that contains this line
 	Account ReconcileTxReconcileTxReconcileTxResultLinesLedgerLineAccountLedgerAccount `json:"account"`
 	// ID in the external system of the transaction linked to this LedgerLine
@@ -3382,7 +3391,9 @@ type ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine struct {
 func (v *ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine) GetId() string { return v.Id }
 
 // GetAmount returns ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine) GetAmount() string { return v.Amount }
+func (v *ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine) GetAmount() fragment.Amount {
+	return v.Amount
+}
 
 // GetAccount returns ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine.Account, and is useful for accessing the field via an interface.
 func (v *ReconcileTxReconcileTxReconcileTxResultLinesLedgerLine) GetAccount() ReconcileTxReconcileTxReconcileTxResultLinesLedgerLineAccountLedgerAccount {
@@ -3642,11 +3653,11 @@ type ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry struct {
 	// The idempotency key used to post this ledger entry
 	Ik string `json:"ik"`
 	// Date this LedgerEntry posted to its Ledger e.g. "2021-01-01".
-	Date string `json:"date"`
+	Date fragment.Date `json:"date"`
 	// ISO-8601 timestamp this LedgerEntry posted to its Ledger.
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 	// ISO-8601 timestamp this LedgerEntry was created in Fragment.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 	// Description posted for this Ledger Entry.
 	Description *string `json:"description"`
 }
@@ -3658,17 +3669,17 @@ func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetId()
 func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetIk() string { return v.Ik }
 
 // GetDate returns ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry.Date, and is useful for accessing the field via an interface.
-func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetDate() string {
+func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetDate() fragment.Date {
 	return v.Date
 }
 
 // GetPosted returns ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry.Posted, and is useful for accessing the field via an interface.
-func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetPosted() string {
+func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetPosted() fragment.DateTime {
 	return v.Posted
 }
 
 // GetCreated returns ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry.Created, and is useful for accessing the field via an interface.
-func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetCreated() string {
+func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -3681,7 +3692,7 @@ func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultEntryLedgerEntry) GetDesc
 type ReconcileTxRuntimeReconcileTxReconcileTxResultLinesLedgerLine struct {
 	Id string `json:"id"`
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// LedgerAccount that contains this line
 	Account ReconcileTxRuntimeReconcileTxReconcileTxResultLinesLedgerLineAccountLedgerAccount `json:"account"`
 	// ID in the external system of the transaction linked to this LedgerLine
@@ -3692,7 +3703,7 @@ type ReconcileTxRuntimeReconcileTxReconcileTxResultLinesLedgerLine struct {
 func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultLinesLedgerLine) GetId() string { return v.Id }
 
 // GetAmount returns ReconcileTxRuntimeReconcileTxReconcileTxResultLinesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultLinesLedgerLine) GetAmount() string {
+func (v *ReconcileTxRuntimeReconcileTxReconcileTxResultLinesLedgerLine) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -4457,13 +4468,15 @@ func (v *StoreSchemaStoreSchemaStoreSchemaResultSchema) GetVersion() StoreSchema
 // A new SchemaVersion is created each time a Schema is stored.
 // It stores the Chart of Accounts and list of Ledger Entries as well as a history of its Ledger migrations.
 type StoreSchemaStoreSchemaStoreSchemaResultSchemaVersion struct {
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 	// The version of the schema.
 	Version int `json:"version"`
 }
 
 // GetCreated returns StoreSchemaStoreSchemaStoreSchemaResultSchemaVersion.Created, and is useful for accessing the field via an interface.
-func (v *StoreSchemaStoreSchemaStoreSchemaResultSchemaVersion) GetCreated() string { return v.Created }
+func (v *StoreSchemaStoreSchemaStoreSchemaResultSchemaVersion) GetCreated() fragment.DateTime {
+	return v.Created
+}
 
 // GetVersion returns StoreSchemaStoreSchemaStoreSchemaResultSchemaVersion.Version, and is useful for accessing the field via an interface.
 func (v *StoreSchemaStoreSchemaStoreSchemaResultSchemaVersion) GetVersion() int { return v.Version }
@@ -4978,11 +4991,11 @@ type SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx struct {
 	// ID in the external system of this transaction's external account
 	ExternalAccountId string `json:"externalAccountId"`
 	// Integer amount in cents. Positive indicates money entering the external account, negative indicates money leaving
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// Description at the external account (can be overridden within the Fragment Dashboard)
 	Description string `json:"description"`
 	// ISO-8601 timestamp this Tx posted to the external account
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 }
 
 // GetTypename returns SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx.Typename, and is useful for accessing the field via an interface.
@@ -5005,7 +5018,9 @@ func (v *SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx) GetExternalAccountI
 }
 
 // GetAmount returns SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx.Amount, and is useful for accessing the field via an interface.
-func (v *SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx) GetAmount() string { return v.Amount }
+func (v *SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx) GetAmount() fragment.Amount {
+	return v.Amount
+}
 
 // GetDescription returns SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx.Description, and is useful for accessing the field via an interface.
 func (v *SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx) GetDescription() string {
@@ -5013,7 +5028,9 @@ func (v *SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx) GetDescription() st
 }
 
 // GetPosted returns SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx.Posted, and is useful for accessing the field via an interface.
-func (v *SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx) GetPosted() string { return v.Posted }
+func (v *SyncCustomTxsSyncCustomTxsSyncCustomTxsResultTxsTx) GetPosted() fragment.DateTime {
+	return v.Posted
+}
 
 // Filters a result set based on the tags it contains.
 type TagFilter struct {
@@ -5328,9 +5345,9 @@ type UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntry s
 	// The idempotency key used to post this ledger entry
 	Ik string `json:"ik"`
 	// ISO-8601 timestamp this LedgerEntry posted to its Ledger.
-	Posted string `json:"posted"`
+	Posted fragment.DateTime `json:"posted"`
 	// ISO-8601 timestamp this LedgerEntry was created in Fragment.
-	Created string `json:"created"`
+	Created fragment.DateTime `json:"created"`
 	// Description posted for this Ledger Entry.
 	Description *string `json:"description"`
 	// Lines posted in this Ledger Entry.
@@ -5352,12 +5369,12 @@ func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEnt
 }
 
 // GetPosted returns UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntry.Posted, and is useful for accessing the field via an interface.
-func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntry) GetPosted() string {
+func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntry) GetPosted() fragment.DateTime {
 	return v.Posted
 }
 
 // GetCreated returns UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntry.Created, and is useful for accessing the field via an interface.
-func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntry) GetCreated() string {
+func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntry) GetCreated() fragment.DateTime {
 	return v.Created
 }
 
@@ -5420,7 +5437,7 @@ func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEnt
 type UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine struct {
 	Id string `json:"id"`
 	// How much this line's LedgerAccount's balance changed in integer cents  (i.e. in USD 100 is 1 dollar, 100 cents)
-	Amount string `json:"amount"`
+	Amount fragment.Amount `json:"amount"`
 	// LedgerAccount that contains this line
 	Account UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLineAccountLedgerAccount `json:"account"`
 }
@@ -5431,7 +5448,7 @@ func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEnt
 }
 
 // GetAmount returns UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine.Amount, and is useful for accessing the field via an interface.
-func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() string {
+func (v *UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResultEntryLedgerEntryLinesLedgerLinesConnectionNodesLedgerLine) GetAmount() fragment.Amount {
 	return v.Amount
 }
 
@@ -5729,7 +5746,7 @@ type __AddLedgerEntryInput struct {
 	Ik         string                  `json:"ik"`
 	LedgerIk   string                  `json:"ledgerIk"`
 	EntryType  string                  `json:"entryType"`
-	Posted     *string                 `json:"posted"`
+	Posted     *fragment.DateTime      `json:"posted"`
 	Parameters json.RawMessage         `json:"parameters"`
 	Tags       []LedgerEntryTagInput   `json:"tags"`
 	Groups     []LedgerEntryGroupInput `json:"groups"`
@@ -5745,7 +5762,7 @@ func (v *__AddLedgerEntryInput) GetLedgerIk() string { return v.LedgerIk }
 func (v *__AddLedgerEntryInput) GetEntryType() string { return v.EntryType }
 
 // GetPosted returns __AddLedgerEntryInput.Posted, and is useful for accessing the field via an interface.
-func (v *__AddLedgerEntryInput) GetPosted() *string { return v.Posted }
+func (v *__AddLedgerEntryInput) GetPosted() *fragment.DateTime { return v.Posted }
 
 // GetParameters returns __AddLedgerEntryInput.Parameters, and is useful for accessing the field via an interface.
 func (v *__AddLedgerEntryInput) GetParameters() json.RawMessage { return v.Parameters }
@@ -5761,7 +5778,7 @@ type __AddLedgerEntryRuntimeInput struct {
 	Ik        string                  `json:"ik"`
 	EntryType string                  `json:"entryType"`
 	LedgerIk  string                  `json:"ledgerIk"`
-	Posted    *string                 `json:"posted"`
+	Posted    *fragment.DateTime      `json:"posted"`
 	Lines     []LedgerLineInput       `json:"lines"`
 	Tags      []LedgerEntryTagInput   `json:"tags"`
 	Groups    []LedgerEntryGroupInput `json:"groups"`
@@ -5777,7 +5794,7 @@ func (v *__AddLedgerEntryRuntimeInput) GetEntryType() string { return v.EntryTyp
 func (v *__AddLedgerEntryRuntimeInput) GetLedgerIk() string { return v.LedgerIk }
 
 // GetPosted returns __AddLedgerEntryRuntimeInput.Posted, and is useful for accessing the field via an interface.
-func (v *__AddLedgerEntryRuntimeInput) GetPosted() *string { return v.Posted }
+func (v *__AddLedgerEntryRuntimeInput) GetPosted() *fragment.DateTime { return v.Posted }
 
 // GetLines returns __AddLedgerEntryRuntimeInput.Lines, and is useful for accessing the field via an interface.
 func (v *__AddLedgerEntryRuntimeInput) GetLines() []LedgerLineInput { return v.Lines }
@@ -5821,7 +5838,7 @@ type __GetLedgerAccountBalanceInput struct {
 	Path                      string                      `json:"path"`
 	LedgerIk                  string                      `json:"ledgerIk"`
 	BalanceCurrency           *CurrencyMatchInput         `json:"balanceCurrency"`
-	BalanceAt                 *string                     `json:"balanceAt"`
+	BalanceAt                 *fragment.LastMoment        `json:"balanceAt"`
 	OwnBalanceConsistencyMode *ReadBalanceConsistencyMode `json:"ownBalanceConsistencyMode"`
 }
 
@@ -5837,7 +5854,7 @@ func (v *__GetLedgerAccountBalanceInput) GetBalanceCurrency() *CurrencyMatchInpu
 }
 
 // GetBalanceAt returns __GetLedgerAccountBalanceInput.BalanceAt, and is useful for accessing the field via an interface.
-func (v *__GetLedgerAccountBalanceInput) GetBalanceAt() *string { return v.BalanceAt }
+func (v *__GetLedgerAccountBalanceInput) GetBalanceAt() *fragment.LastMoment { return v.BalanceAt }
 
 // GetOwnBalanceConsistencyMode returns __GetLedgerAccountBalanceInput.OwnBalanceConsistencyMode, and is useful for accessing the field via an interface.
 func (v *__GetLedgerAccountBalanceInput) GetOwnBalanceConsistencyMode() *ReadBalanceConsistencyMode {
@@ -5911,7 +5928,7 @@ type __ListLedgerAccountBalancesInput struct {
 	First                     *int                        `json:"first"`
 	Before                    *string                     `json:"before"`
 	BalanceCurrency           *CurrencyMatchInput         `json:"balanceCurrency"`
-	BalanceAt                 *string                     `json:"balanceAt"`
+	BalanceAt                 *fragment.LastMoment        `json:"balanceAt"`
 	OwnBalanceConsistencyMode *ReadBalanceConsistencyMode `json:"ownBalanceConsistencyMode"`
 }
 
@@ -5933,7 +5950,7 @@ func (v *__ListLedgerAccountBalancesInput) GetBalanceCurrency() *CurrencyMatchIn
 }
 
 // GetBalanceAt returns __ListLedgerAccountBalancesInput.BalanceAt, and is useful for accessing the field via an interface.
-func (v *__ListLedgerAccountBalancesInput) GetBalanceAt() *string { return v.BalanceAt }
+func (v *__ListLedgerAccountBalancesInput) GetBalanceAt() *fragment.LastMoment { return v.BalanceAt }
 
 // GetOwnBalanceConsistencyMode returns __ListLedgerAccountBalancesInput.OwnBalanceConsistencyMode, and is useful for accessing the field via an interface.
 func (v *__ListLedgerAccountBalancesInput) GetOwnBalanceConsistencyMode() *ReadBalanceConsistencyMode {
@@ -5990,7 +6007,7 @@ type __ListMultiCurrencyLedgerAccountBalancesInput struct {
 	After                      *string                     `json:"after"`
 	First                      *int                        `json:"first"`
 	Before                     *string                     `json:"before"`
-	BalanceAt                  *string                     `json:"balanceAt"`
+	BalanceAt                  *fragment.LastMoment        `json:"balanceAt"`
 	OwnBalancesConsistencyMode *ReadBalanceConsistencyMode `json:"ownBalancesConsistencyMode"`
 }
 
@@ -6007,7 +6024,9 @@ func (v *__ListMultiCurrencyLedgerAccountBalancesInput) GetFirst() *int { return
 func (v *__ListMultiCurrencyLedgerAccountBalancesInput) GetBefore() *string { return v.Before }
 
 // GetBalanceAt returns __ListMultiCurrencyLedgerAccountBalancesInput.BalanceAt, and is useful for accessing the field via an interface.
-func (v *__ListMultiCurrencyLedgerAccountBalancesInput) GetBalanceAt() *string { return v.BalanceAt }
+func (v *__ListMultiCurrencyLedgerAccountBalancesInput) GetBalanceAt() *fragment.LastMoment {
+	return v.BalanceAt
+}
 
 // GetOwnBalancesConsistencyMode returns __ListMultiCurrencyLedgerAccountBalancesInput.OwnBalancesConsistencyMode, and is useful for accessing the field via an interface.
 func (v *__ListMultiCurrencyLedgerAccountBalancesInput) GetOwnBalancesConsistencyMode() *ReadBalanceConsistencyMode {
@@ -6157,7 +6176,7 @@ func AddLedgerEntry(
 	ik string,
 	ledgerIk string,
 	entryType string,
-	posted *string,
+	posted *fragment.DateTime,
 	parameters json.RawMessage,
 	tags []LedgerEntryTagInput,
 	groups []LedgerEntryGroupInput,
@@ -6230,7 +6249,7 @@ func AddLedgerEntryRuntime(
 	ik string,
 	entryType string,
 	ledgerIk string,
-	posted *string,
+	posted *fragment.DateTime,
 	lines []LedgerLineInput,
 	tags []LedgerEntryTagInput,
 	groups []LedgerEntryGroupInput,
@@ -6443,7 +6462,7 @@ func GetLedgerAccountBalance(
 	path string,
 	ledgerIk string,
 	balanceCurrency *CurrencyMatchInput,
-	balanceAt *string,
+	balanceAt *fragment.LastMoment,
 	ownBalanceConsistencyMode *ReadBalanceConsistencyMode,
 ) (*GetLedgerAccountBalanceResponse, error) {
 	req_ := &graphql.Request{
@@ -6720,7 +6739,7 @@ func ListLedgerAccountBalances(
 	first *int,
 	before *string,
 	balanceCurrency *CurrencyMatchInput,
-	balanceAt *string,
+	balanceAt *fragment.LastMoment,
 	ownBalanceConsistencyMode *ReadBalanceConsistencyMode,
 ) (*ListLedgerAccountBalancesResponse, error) {
 	req_ := &graphql.Request{
@@ -6948,7 +6967,7 @@ func ListMultiCurrencyLedgerAccountBalances(
 	after *string,
 	first *int,
 	before *string,
-	balanceAt *string,
+	balanceAt *fragment.LastMoment,
 	ownBalancesConsistencyMode *ReadBalanceConsistencyMode,
 ) (*ListMultiCurrencyLedgerAccountBalancesResponse, error) {
 	req_ := &graphql.Request{
@@ -0,0 +1,69 @@
+package queries
+
+import "github.com/fragment-dev/fragment-go/fragment"
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *AddLedgerEntryResponse) Result() (*AddLedgerEntryAddLedgerEntryAddLedgerEntryResult, error) {
+	return fragment.Unwrap[*AddLedgerEntryAddLedgerEntryAddLedgerEntryResult](v.AddLedgerEntry)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *AddLedgerEntryRuntimeResponse) Result() (*AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResult, error) {
+	return fragment.Unwrap[*AddLedgerEntryRuntimeAddLedgerEntryAddLedgerEntryResult](v.AddLedgerEntry)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *CreateCustomLinkResponse) Result() (*CreateCustomLinkCreateCustomLinkCreateCustomLinkResult, error) {
+	return fragment.Unwrap[*CreateCustomLinkCreateCustomLinkCreateCustomLinkResult](v.CreateCustomLink)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *CreateLedgerResponse) Result() (*CreateLedgerCreateLedgerCreateLedgerResult, error) {
+	return fragment.Unwrap[*CreateLedgerCreateLedgerCreateLedgerResult](v.CreateLedger)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *ReconcileTxResponse) Result() (*ReconcileTxReconcileTxReconcileTxResult, error) {
+	return fragment.Unwrap[*ReconcileTxReconcileTxReconcileTxResult](v.ReconcileTx)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *ReconcileTxRuntimeResponse) Result() (*ReconcileTxRuntimeReconcileTxReconcileTxResult, error) {
+	return fragment.Unwrap[*ReconcileTxRuntimeReconcileTxReconcileTxResult](v.ReconcileTx)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *StoreSchemaResponse) Result() (*StoreSchemaStoreSchemaStoreSchemaResult, error) {
+	return fragment.Unwrap[*StoreSchemaStoreSchemaStoreSchemaResult](v.StoreSchema)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *SyncCustomAccountsResponse) Result() (*SyncCustomAccountsSyncCustomAccountsSyncCustomAccountsResult, error) {
+	return fragment.Unwrap[*SyncCustomAccountsSyncCustomAccountsSyncCustomAccountsResult](v.SyncCustomAccounts)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *SyncCustomTxsResponse) Result() (*SyncCustomTxsSyncCustomTxsSyncCustomTxsResult, error) {
+	return fragment.Unwrap[*SyncCustomTxsSyncCustomTxsSyncCustomTxsResult](v.SyncCustomTxs)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *UpdateLedgerEntryResponse) Result() (*UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResult, error) {
+	return fragment.Unwrap[*UpdateLedgerEntryUpdateLedgerEntryUpdateLedgerEntryResult](v.UpdateLedgerEntry)
+}
+
+// Result returns the result of the mutation, or the *fragment.APIError
+// returned instead.
+func (v *UpdateLedgerResponse) Result() (*UpdateLedgerUpdateLedgerUpdateLedgerResult, error) {
+	return fragment.Unwrap[*UpdateLedgerUpdateLedgerUpdateLedgerResult](v.UpdateLedger)
+}
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+// AccountBuilder builds a Ledger Account of a Chart of Accounts, and its
+// children.
+type AccountBuilder struct {
+	input    queries.SchemaLedgerAccountInput
+	children []*AccountBuilder
+}
+
+// Account returns an AccountBuilder for the Ledger Account with key.
+func Account(key string) *AccountBuilder {
+	return &AccountBuilder{input: queries.SchemaLedgerAccountInput{Key: key}}
+}
+
+// Template returns an AccountBuilder for the templated Ledger Account with
+// key, which is instantiated for every value of a parameter, such as a user
+// ID. Lines post to an instance with a path segment from Instance.
+func Template(key string) *AccountBuilder {
+	template := true
+	a := Account(key)
+	a.input.Template = &template
+	return a
+}
+
+// Type sets the type of the account. It's required for top-level accounts,
+// and inherited from the parent otherwise.
+func (a *AccountBuilder) Type(accountType queries.LedgerAccountTypes) *AccountBuilder {
+	a.input.Type = &accountType
+	return a
+}
+
+// Name sets the human-readable name of the account.
+func (a *AccountBuilder) Name(name string) *AccountBuilder {
+	a.input.Name = &name
+	return a
+}
+
+// Currency sets the currency of a single-currency account, in place of the
+// default currency of the Chart of Accounts.
+func (a *AccountBuilder) Currency(currency queries.CurrencyMatchInput) *AccountBuilder {
+	schemaCurrency := CurrencyOf(currency)
+	a.input.Currency = &schemaCurrency
+	return a
+}
+
+// CurrencyMode sets whether the account holds a single currency or many.
+func (a *AccountBuilder) CurrencyMode(mode queries.CurrencyMode) *AccountBuilder {
+	a.input.CurrencyMode = &mode
+	return a
+}
+
+// LinkedAccount links the account to an External Account.
+func (a *AccountBuilder) LinkedAccount(account queries.SchemaExternalAccountMatchInput) *AccountBuilder {
+	a.input.LinkedAccount = &account
+	return a
+}
+
+// Consistency sets the consistency configuration of the account.
+func (a *AccountBuilder) Consistency(config queries.LedgerAccountConsistencyConfigInput) *AccountBuilder {
+	a.input.ConsistencyConfig = &config
+	return a
+}
+
+// Children adds child accounts to the account.
+func (a *AccountBuilder) Children(children ...*AccountBuilder) *AccountBuilder {
+	a.children = append(a.children, children...)
+	return a
+}
+
+func (a *AccountBuilder) isTemplate() bool {
+	return a.input.Template != nil && *a.input.Template
+}
+
+func (a *AccountBuilder) build() queries.SchemaLedgerAccountInput {
+	input := a.input
+	input.Children = buildAccounts(a.children)
+	return input
+}
+
+func buildAccounts(accounts []*AccountBuilder) []queries.SchemaLedgerAccountInput {
+	if len(accounts) == 0 {
+		return nil
+	}
+	inputs := make([]queries.SchemaLedgerAccountInput, len(accounts))
+	for i, account := range accounts {
+		inputs[i] = account.build()
+	}
+	return inputs
+}
+
+// CurrencyOf returns the SchemaCurrencyMatchInput of currency.
+func CurrencyOf(currency queries.CurrencyMatchInput) queries.SchemaCurrencyMatchInput {
+	return queries.SchemaCurrencyMatchInput{Code: string(currency.Code), CustomCurrencyId: currency.CustomCurrencyId}
+}
@@ -0,0 +1,102 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+// Param returns a reference to the parameter name, "{{name}}", which is
+// replaced by its value when an entry is posted.
+func Param(name string) string {
+	return "{{" + name + "}}"
+}
+
+// Instance returns the path segment of the instance of a templated account
+// with key, such as Instance("user", Param("user_id")) for "user:{{user_id}}".
+func Instance(key string, value string) string {
+	return key + ":" + value
+}
+
+// LineOption sets optional fields of a Ledger Line of an entry type.
+type LineOption func(*queries.SchemaLedgerLineInput)
+
+// WithCurrency sets the currency of a line, which is required for lines in
+// multi-currency accounts. Its code may reference a parameter.
+func WithCurrency(currency queries.SchemaCurrencyMatchInput) LineOption {
+	return func(line *queries.SchemaLedgerLineInput) {
+		line.Currency = &currency
+	}
+}
+
+// WithDescription sets the human-readable description of a line.
+func WithDescription(description string) LineOption {
+	return func(line *queries.SchemaLedgerLineInput) {
+		line.Description = &description
+	}
+}
+
+// WithTx sets the external transaction a line reconciles, which is required
+// for lines in Linked Ledger Accounts.
+func WithTx(tx queries.SchemaTxMatchInput) LineOption {
+	return func(line *queries.SchemaLedgerLineInput) {
+		line.Tx = &tx
+	}
+}
+
+// EntryTypeBuilder builds a Ledger Entry type.
+type EntryTypeBuilder struct {
+	input queries.SchemaLedgerEntryInput
+}
+
+// EntryType returns an EntryTypeBuilder for the Ledger Entry type entryType.
+func EntryType(entryType string) *EntryTypeBuilder {
+	return &EntryTypeBuilder{input: queries.SchemaLedgerEntryInput{Type: entryType}}
+}
+
+// Description sets the description of entries of the type. It may reference
+// parameters.
+func (e *EntryTypeBuilder) Description(description string) *EntryTypeBuilder {
+	e.input.Description = &description
+	return e
+}
+
+// Line adds a line with key, posting amount to the Ledger Account at path.
+// The path and amount may reference parameters, and the amount may add and
+// subtract them, such as Param("amount") + " - " + Param("fee").
+func (e *EntryTypeBuilder) Line(key string, path string, amount string, opts ...LineOption) *EntryTypeBuilder {
+	line := queries.SchemaLedgerLineInput{
+		Key:     key,
+		Account: queries.SchemaLedgerAccountMatchInput{Path: path},
+		Amount:  &amount,
+	}
+	for _, opt := range opts {
+		opt(&line)
+	}
+	e.input.Lines = append(e.input.Lines, line)
+	return e
+}
+
+// Condition adds a condition that must be met to post entries of the type.
+func (e *EntryTypeBuilder) Condition(condition queries.SchemaLedgerEntryConditionInput) *EntryTypeBuilder {
+	e.input.Conditions = append(e.input.Conditions, condition)
+	return e
+}
+
+// Tag adds a tag to entries of the type.
+func (e *EntryTypeBuilder) Tag(key string, value string) *EntryTypeBuilder {
+	e.input.Tags = append(e.input.Tags, queries.SchemaLedgerEntryTagInput{Key: key, Value: value})
+	return e
+}
+
+// Group adds entries of the type to a Ledger Entry Group.
+func (e *EntryTypeBuilder) Group(key string, value string) *EntryTypeBuilder {
+	e.input.Groups = append(e.input.Groups, queries.SchemaLedgerEntryGroupInput{Key: key, Value: value})
+	return e
+}
+
+// Parameters sets fixed parameters of entries of the type.
+func (e *EntryTypeBuilder) Parameters(parameters json.RawMessage) *EntryTypeBuilder {
+	e.input.Parameters = &parameters
+	return e
+}
@@ -0,0 +1,249 @@
+// Package schema builds the SchemaInput stored by StoreSchema, and checks it
+// for the mistakes Fragment would otherwise reject it for:
+//
+//	input, err := schema.New("your-schema").
+//		DefaultCurrency(money.Code(queries.CurrencyCodeUsd)).
+//		Accounts(
+//			schema.Account("assets-root").Type(queries.LedgerAccountTypesAsset).Children(
+//				schema.Account("bank"),
+//			),
+//			schema.Account("liabilities").Type(queries.LedgerAccountTypesLiability).Children(
+//				schema.Template("user").Children(schema.Account("available")),
+//			),
+//		).
+//		EntryTypes(
+//			schema.EntryType("user_funds_account").
+//				Line("bank", "assets-root/bank", schema.Param("amount")).
+//				Line("available", "liabilities/user:"+schema.Param("user_id")+"/available", schema.Param("amount")),
+//		).
+//		Build()
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fragment-dev/fragment-go/auth"
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+// MaxDepth is the maximum depth to which Ledger Accounts may be nested.
+const MaxDepth = 10
+
+// Builder builds a SchemaInput.
+type Builder struct {
+	input    queries.SchemaInput
+	accounts []*AccountBuilder
+	entries  []*EntryTypeBuilder
+}
+
+// New returns a Builder for the Schema with key.
+func New(key string) *Builder {
+	return &Builder{input: queries.SchemaInput{Key: key}}
+}
+
+// Name sets the human-readable name of the Schema.
+func (b *Builder) Name(name string) *Builder {
+	b.input.Name = &name
+	return b
+}
+
+// DefaultCurrency sets the currency of accounts that don't set their own.
+// Unless DefaultCurrencyMode is set, it also sets it to single.
+func (b *Builder) DefaultCurrency(currency queries.CurrencyMatchInput) *Builder {
+	b.input.ChartOfAccounts.DefaultCurrency = &currency
+	if b.input.ChartOfAccounts.DefaultCurrencyMode == nil {
+		b.DefaultCurrencyMode(queries.CurrencyModeSingle)
+	}
+	return b
+}
+
+// DefaultCurrencyMode sets whether accounts that don't set their own hold a
+// single currency or many.
+func (b *Builder) DefaultCurrencyMode(mode queries.CurrencyMode) *Builder {
+	b.input.ChartOfAccounts.DefaultCurrencyMode = &mode
+	return b
+}
+
+// DefaultConsistency sets the consistency configuration of accounts that
+// don't set their own.
+func (b *Builder) DefaultConsistency(config queries.LedgerAccountConsistencyConfigInput) *Builder {
+	b.input.ChartOfAccounts.DefaultConsistencyConfig = &config
+	return b
+}
+
+// EntriesConsistency sets the consistency mode of the Ledger Entries list
+// query of Ledgers created with the Schema.
+func (b *Builder) EntriesConsistency(mode queries.SchemaConsistencyMode) *Builder {
+	b.input.ConsistencyConfig = &queries.SchemaConsistencyConfigInput{Entries: &mode}
+	return b
+}
+
+// Accounts adds top-level accounts to the Chart of Accounts.
+func (b *Builder) Accounts(accounts ...*AccountBuilder) *Builder {
+	b.accounts = append(b.accounts, accounts...)
+	return b
+}
+
+// EntryTypes adds Ledger Entry types to the Schema.
+func (b *Builder) EntryTypes(entries ...*EntryTypeBuilder) *Builder {
+	b.entries = append(b.entries, entries...)
+	return b
+}
+
+// Build checks the Schema, and returns its SchemaInput. The error lists every
+// problem found.
+func (b *Builder) Build() (queries.SchemaInput, error) {
+	if err := b.Validate(); err != nil {
+		return queries.SchemaInput{}, err
+	}
+	input := b.input
+	input.ChartOfAccounts.Accounts = buildAccounts(b.accounts)
+	if len(b.entries) > 0 {
+		input.LedgerEntries = &queries.SchemaLedgerEntriesInput{}
+		for _, entry := range b.entries {
+			input.LedgerEntries.Types = append(input.LedgerEntries.Types, entry.input)
+		}
+	}
+	return input, nil
+}
+
+// Store builds the Schema, then stores it with StoreSchema.
+func (b *Builder) Store(ctx auth.AuthenticatedContext) (*queries.StoreSchemaResponse, error) {
+	input, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return queries.StoreSchema(ctx, input)
+}
+
+// Validate returns an error listing every problem with the Schema:
+//   - a missing key, or default currency inconsistent with the default
+//     currency mode
+//   - no accounts, accounts nested deeper than MaxDepth, siblings with the
+//     same key, top-level accounts without a type, or multi-currency accounts
+//     with a currency
+//   - entry types or line keys that aren't unique, or lines and conditions
+//     on accounts that aren't in the Chart of Accounts
+func (b *Builder) Validate() error {
+	var errs []error
+	if b.input.Key == "" {
+		errs = append(errs, fmt.Errorf("You must provide a Schema key"))
+	}
+
+	chart := b.input.ChartOfAccounts
+	mode := chart.DefaultCurrencyMode
+	switch {
+	case mode != nil && *mode == queries.CurrencyModeSingle && chart.DefaultCurrency == nil:
+		errs = append(errs, fmt.Errorf("You must provide a defaultCurrency when defaultCurrencyMode is single"))
+	case mode != nil && *mode == queries.CurrencyModeMulti && chart.DefaultCurrency != nil:
+		errs = append(errs, fmt.Errorf("You must omit defaultCurrency when defaultCurrencyMode is multi"))
+	}
+
+	if len(b.accounts) == 0 {
+		errs = append(errs, fmt.Errorf("You must provide at least one Ledger Account"))
+	}
+	errs = append(errs, validateAccounts(b.accounts, "", 1)...)
+
+	entryTypes := map[string]bool{}
+	for _, entry := range b.entries {
+		errs = append(errs, b.validateEntry(entry, entryTypes)...)
+	}
+	return errors.Join(errs...)
+}
+
+func validateAccounts(accounts []*AccountBuilder, parent string, depth int) []error {
+	var errs []error
+	keys := map[string]bool{}
+	for _, account := range accounts {
+		key := account.input.Key
+		path := key
+		if parent != "" {
+			path = parent + "/" + key
+		}
+
+		if key == "" {
+			errs = append(errs, fmt.Errorf("You must provide a key for every Ledger Account, one is missing under %q", parent))
+		} else if keys[key] {
+			errs = append(errs, fmt.Errorf("Ledger Account %s has a sibling with the same key", path))
+		}
+		keys[key] = true
+		if depth > MaxDepth {
+			errs = append(errs, fmt.Errorf("Ledger Account %s is nested deeper than the maximum depth of %d", path, MaxDepth))
+			continue
+		}
+		if depth == 1 && account.input.Type == nil {
+			errs = append(errs, fmt.Errorf("You must provide a type for top-level Ledger Account %s", path))
+		}
+		if mode := account.input.CurrencyMode; mode != nil && *mode == queries.CurrencyModeMulti && account.input.Currency != nil {
+			errs = append(errs, fmt.Errorf("Ledger Account %s must not have a currency, as its currencyMode is multi", path))
+		}
+		errs = append(errs, validateAccounts(account.children, path, depth+1)...)
+	}
+	return errs
+}
+
+func (b *Builder) validateEntry(entry *EntryTypeBuilder, entryTypes map[string]bool) []error {
+	var errs []error
+	entryType := entry.input.Type
+	if entryType == "" {
+		errs = append(errs, fmt.Errorf("You must provide a type for every Ledger Entry type"))
+	} else if entryTypes[entryType] {
+		errs = append(errs, fmt.Errorf("Ledger Entry type %s is defined more than once", entryType))
+	}
+	entryTypes[entryType] = true
+
+	lineKeys := map[string]bool{}
+	for _, line := range entry.input.Lines {
+		if line.Key == "" {
+			errs = append(errs, fmt.Errorf("Ledger Entry type %s: you must provide a key for every line", entryType))
+		} else if lineKeys[line.Key] {
+			errs = append(errs, fmt.Errorf("Ledger Entry type %s: line key %s is used more than once", entryType, line.Key))
+		}
+		lineKeys[line.Key] = true
+		if err := b.resolve(line.Account.Path); err != nil {
+			errs = append(errs, fmt.Errorf("Ledger Entry type %s: line %s: %w", entryType, line.Key, err))
+		}
+	}
+	for _, condition := range entry.input.Conditions {
+		if err := b.resolve(condition.Account.Path); err != nil {
+			errs = append(errs, fmt.Errorf("Ledger Entry type %s: condition: %w", entryType, err))
+		}
+	}
+	return errs
+}
+
+// resolve returns an error if path doesn't match an account in the Chart of
+// Accounts, with an instance for every templated account on it. Paths whose
+// keys are parameters can't be resolved until an entry is posted, so they
+// are only checked up to the first such key.
+func (b *Builder) resolve(path string) error {
+	accounts := b.accounts
+	var resolved []string
+	for _, segment := range strings.Split(path, "/") {
+		key, _, isInstance := strings.Cut(segment, ":")
+		if strings.Contains(key, "{{") {
+			return nil
+		}
+		resolved = append(resolved, key)
+
+		var account *AccountBuilder
+		for _, a := range accounts {
+			if a.input.Key == key {
+				account = a
+				break
+			}
+		}
+		switch {
+		case account == nil:
+			return fmt.Errorf("Ledger Account %s is not in the Chart of Accounts", strings.Join(resolved, "/"))
+		case account.isTemplate() && !isInstance:
+			return fmt.Errorf("Ledger Account %s is templated, so its path segment must be %s", strings.Join(resolved, "/"), Instance(key, Param("parameter")))
+		case !account.isTemplate() && isInstance:
+			return fmt.Errorf("Ledger Account %s is not templated, so its path segment must be %s", strings.Join(resolved, "/"), key)
+		}
+		accounts = account.children
+	}
+	return nil
+}
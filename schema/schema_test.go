@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fragment-dev/fragment-go/queries"
+)
+
+var usd = queries.CurrencyMatchInput{Code: queries.CurrencyCodeUsd}
+
+func newSchema() *Builder {
+	return New("your-schema").
+		Name("Your schema").
+		DefaultCurrency(usd).
+		Accounts(
+			Account("assets-root").Type(queries.LedgerAccountTypesAsset).Children(
+				Account("bank"),
+			),
+			Account("liabilities").Type(queries.LedgerAccountTypesLiability).Children(
+				Template("user").Children(Account("available")),
+			),
+		).
+		EntryTypes(
+			EntryType("user_funds_account").
+				Description("Fund "+Param("user_id")).
+				Line("bank", "assets-root/bank", Param("amount")).
+				Line("available", "liabilities/"+Instance("user", Param("user_id"))+"/available", Param("amount")).
+				Tag("user", Param("user_id")),
+		)
+}
+
+func TestBuild(t *testing.T) {
+	input, err := newSchema().Build()
+	if err != nil {
+		t.Fatalf("Got error from Build: %s", err)
+	}
+
+	encoded, err := json.Marshal(input)
+	if err != nil {
+		t.Fatalf("Got error from Marshal: %s", err)
+	}
+	expected := `{"chartOfAccounts":{"accounts":[` +
+		`{"children":[{"children":null,"consistencyConfig":null,"currency":null,"currencyMode":null,"key":"bank","linkedAccount":null,"name":null,"template":null,"type":null}],"consistencyConfig":null,"currency":null,"currencyMode":null,"key":"assets-root","linkedAccount":null,"name":null,"template":null,"type":"asset"},` +
+		`{"children":[{"children":[{"children":null,"consistencyConfig":null,"currency":null,"currencyMode":null,"key":"available","linkedAccount":null,"name":null,"template":null,"type":null}],"consistencyConfig":null,"currency":null,"currencyMode":null,"key":"user","linkedAccount":null,"name":null,"template":true,"type":null}],"consistencyConfig":null,"currency":null,"currencyMode":null,"key":"liabilities","linkedAccount":null,"name":null,"template":null,"type":"liability"}` +
+		`],"defaultConsistencyConfig":null,"defaultCurrency":{"code":"USD","customCurrencyId":null},"defaultCurrencyMode":"single"},` +
+		`"consistencyConfig":null,"key":"your-schema","ledgerEntries":{"types":[{"conditions":null,"description":"Fund {{user_id}}","groups":null,` +
+		`"lines":[{"account":{"path":"assets-root/bank"},"amount":"{{amount}}","currency":null,"description":null,"key":"bank","tx":null},` +
+		`{"account":{"path":"liabilities/user:{{user_id}}/available"},"amount":"{{amount}}","currency":null,"description":null,"key":"available","tx":null}],` +
+		`"parameters":null,"tags":[{"key":"user","value":"{{user_id}}"}],"type":"user_funds_account"}]},"name":"Your schema","scenes":null}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+}
+
+func TestValidateDepth(t *testing.T) {
+	deepest := Account("level-10")
+	root := deepest
+	for level := 9; level >= 1; level-- {
+		root = Account("level-" + string(rune('0'+level))).Children(root)
+	}
+	root.Type(queries.LedgerAccountTypesAsset)
+	if err := New("deep").Accounts(root).Validate(); err != nil {
+		t.Errorf("Expected accounts nested %d deep to be valid, got %s", MaxDepth, err)
+	}
+
+	deepest.Children(Account("level-11"))
+	err := New("deep").Accounts(root).Validate()
+	if err == nil || !strings.Contains(err.Error(), "level-10/level-11 is nested deeper than the maximum depth of 10") {
+		t.Errorf("Expected a depth error, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	multi := queries.CurrencyModeMulti
+	for _, test := range []struct {
+		name     string
+		schema   *Builder
+		expected string
+	}{
+		{
+			"no accounts",
+			New("your-schema"),
+			"You must provide at least one Ledger Account",
+		},
+		{
+			"duplicate siblings",
+			newSchema().Accounts(Account("liabilities").Type(queries.LedgerAccountTypesLiability)),
+			"Ledger Account liabilities has a sibling with the same key",
+		},
+		{
+			"untyped top-level account",
+			newSchema().Accounts(Account("income-root")),
+			"You must provide a type for top-level Ledger Account income-root",
+		},
+		{
+			"multi-currency default",
+			newSchema().DefaultCurrencyMode(multi),
+			"You must omit defaultCurrency when defaultCurrencyMode is multi",
+		},
+		{
+			"single-currency default without a currency",
+			New("your-schema").DefaultCurrencyMode(queries.CurrencyModeSingle),
+			"You must provide a defaultCurrency when defaultCurrencyMode is single",
+		},
+		{
+			"multi-currency account with a currency",
+			newSchema().Accounts(Account("fx").Type(queries.LedgerAccountTypesAsset).CurrencyMode(multi).Currency(usd)),
+			"Ledger Account fx must not have a currency",
+		},
+		{
+			"unknown account",
+			newSchema().EntryTypes(EntryType("refund").Line("bank", "assets-root/cash", Param("amount"))),
+			"Ledger Entry type refund: line bank: Ledger Account assets-root/cash is not in the Chart of Accounts",
+		},
+		{
+			"template without an instance",
+			newSchema().EntryTypes(EntryType("refund").Line("available", "liabilities/user/available", Param("amount"))),
+			"Ledger Account liabilities/user is templated",
+		},
+		{
+			"duplicate entry type and line key",
+			newSchema().EntryTypes(EntryType("user_funds_account").Line("bank", "assets-root/bank", "1").Line("bank", "assets-root/bank", "-1")),
+			"Ledger Entry type user_funds_account is defined more than once",
+		},
+	} {
+		err := test.schema.Validate()
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.expected, err)
+		}
+		if _, err := test.schema.Build(); err == nil {
+			t.Errorf("%s: expected Build to fail", test.name)
+		}
+	}
+
+	err := newSchema().EntryTypes(EntryType("transfer").Line("from", "assets-root/bank", "1").Line("from", Param("to"), "-1")).Validate()
+	if err == nil || !strings.Contains(err.Error(), "line key from is used more than once") || strings.Contains(err.Error(), "{{to}}") {
+		t.Errorf("Expected only a duplicate line key error, got %v", err)
+	}
+}